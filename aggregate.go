@@ -0,0 +1,282 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AggregateStep a single step of a FT.AGGREGATE pipeline. See Aggregate* functions
+type AggregateStep []interface{}
+
+// Reducer a reduce function used within a GROUPBY step. See Reducer* functions
+type Reducer []interface{}
+
+type AggregateOptions struct {
+	// IndexName The index name. The index must be first created with FT.CREATE .
+	IndexName string
+	// Query base filtering query that retrieves the documents. It follows the exact same syntax as the search query.
+	// Defaults to * (all documents)
+	Query string
+	// Verbatim if set, we do not try to use stemming for query expansion but search the query terms verbatim.
+	Verbatim bool
+	// Steps the pipeline to apply over the matching documents. Steps are executed in the given order.
+	Steps []AggregateStep
+	// Params values bound to the $name placeholders of the query. See SearchOptions.Params
+	Params map[string]interface{}
+	// Dialect the query dialect version. See SearchOptions.Dialect
+	Dialect int
+}
+
+// AggregateLoad Load document fields from the HASH object. This should be avoided as a general rule of thumb.
+// Fields needed for aggregations should be stored as SORTABLE, where they are available to the aggregation pipeline with very low latency.
+// If no field is provided all the document fields are loaded (LOAD *)
+func AggregateLoad(fields ...string) AggregateStep {
+	if len(fields) == 0 {
+		return []interface{}{"LOAD", "*"}
+	}
+	step := []interface{}{"LOAD", len(fields)}
+	for _, field := range fields {
+		step = append(step, property(field))
+	}
+	return step
+}
+
+// AggregateGroupBy Group the results in the pipeline based on one or more properties.
+// Each group should have at least one reducer, a function that handles the group entries, either counting them, or performing multiple aggregate operations
+func AggregateGroupBy(fields []string, reducers ...Reducer) AggregateStep {
+	step := []interface{}{"GROUPBY", len(fields)}
+	for _, field := range fields {
+		step = append(step, property(field))
+	}
+	for _, reducer := range reducers {
+		step = append(step, reducer...)
+	}
+	return step
+}
+
+// AggregateApply Apply a 1-to-1 transformation on one or more properties, and either store the result as a new property down the pipeline,
+// or replace any property using this transformation. {expression} is an expression that can be used to perform arithmetic operations on numeric properties,
+// or functions that can be applied on properties depending on their types
+func AggregateApply(expression, as string) AggregateStep {
+	return []interface{}{"APPLY", expression, "AS", as}
+}
+
+// AggregateFilter Filter the results using predicate expressions relating to values in each result.
+// They are applied post-query and relate to the current state of the pipeline.
+func AggregateFilter(expression string) AggregateStep {
+	return []interface{}{"FILTER", expression}
+}
+
+// AggregateSortBy Sort the pipeline up until the point of SORTBY, using a list of properties (at least one is required).
+// If {max} is greater than 0 only the first {max} results are sorted, which is much more efficient than sorting all the results
+func AggregateSortBy(max int, field SortBy, more ...SortBy) AggregateStep {
+	fields := append([]SortBy{field}, more...)
+	step := []interface{}{"SORTBY", len(fields) * 2}
+	for _, field := range fields {
+		order := "ASC"
+		if field.Descending {
+			order = "DESC"
+		}
+		step = append(step, property(field.FieldName), order)
+	}
+	if max > 0 {
+		step = append(step, "MAX", max)
+	}
+	return step
+}
+
+// AggregateLimit Limit the number of results to return just {max} results starting at index {offset} (zero-based)
+func AggregateLimit(offset, max int) AggregateStep {
+	return []interface{}{"LIMIT", offset, max}
+}
+
+// ReducerCount Count the number of records in each group
+func ReducerCount(as string) Reducer {
+	return reducer("COUNT", as)
+}
+
+// ReducerCountDistinct Count the number of distinct values for {field}
+func ReducerCountDistinct(field, as string) Reducer {
+	return reducer("COUNT_DISTINCT", as, property(field))
+}
+
+// ReducerCountDistinctish Same as ReducerCountDistinct but provide an approximation instead of an exact count,
+// at the expense of less memory and CPU in big groups
+func ReducerCountDistinctish(field, as string) Reducer {
+	return reducer("COUNT_DISTINCTISH", as, property(field))
+}
+
+// ReducerSum Return the sum of all numeric values of a given property in a group. Non numeric values if the group are counted as 0
+func ReducerSum(field, as string) Reducer {
+	return reducer("SUM", as, property(field))
+}
+
+// ReducerMin Return the minimal value of a property, whether it is a string, number or NULL
+func ReducerMin(field, as string) Reducer {
+	return reducer("MIN", as, property(field))
+}
+
+// ReducerMax Return the maximal value of a property, whether it is a string, number or NULL
+func ReducerMax(field, as string) Reducer {
+	return reducer("MAX", as, property(field))
+}
+
+// ReducerAvg Return the average value of a numeric property
+func ReducerAvg(field, as string) Reducer {
+	return reducer("AVG", as, property(field))
+}
+
+// ReducerStdDev Return the standard deviation of a numeric property in the group
+func ReducerStdDev(field, as string) Reducer {
+	return reducer("STDDEV", as, property(field))
+}
+
+// ReducerQuantile Return the value of a numeric property at a given {quantile} of the results. Quantile is expressed as a number between 0 and 1
+func ReducerQuantile(field string, quantile float64, as string) Reducer {
+	return reducer("QUANTILE", as, property(field), strconv.FormatFloat(quantile, 'f', -1, 64))
+}
+
+// ReducerToList Merge all distinct values of a given property into a single array
+func ReducerToList(field, as string) Reducer {
+	return reducer("TOLIST", as, property(field))
+}
+
+// ReducerFirstValue Return the first or top value of a given property in the group, optionally by comparing that or another property.
+// If {by} is empty the first value found in the group is returned
+func ReducerFirstValue(field string, by *SortBy, as string) Reducer {
+	args := []interface{}{property(field)}
+	if by != nil {
+		order := "ASC"
+		if by.Descending {
+			order = "DESC"
+		}
+		args = append(args, "BY", property(by.FieldName), order)
+	}
+	return reducer("FIRST_VALUE", as, args...)
+}
+
+// ReducerRandomSample Perform a reservoir sampling of the group elements with a given {size}, and return an array of the sampled items with an even distribution
+func ReducerRandomSample(field string, size int, as string) Reducer {
+	return reducer("RANDOM_SAMPLE", as, property(field), size)
+}
+
+func reducer(name, as string, args ...interface{}) Reducer {
+	r := []interface{}{"REDUCE", name, len(args)}
+	r = append(r, args...)
+	if as != "" {
+		r = append(r, "AS", as)
+	}
+	return r
+}
+
+// property prepend @ to the given field name if not present
+func property(field string) string {
+	if strings.HasPrefix(field, "@") {
+		return field
+	}
+	return "@" + field
+}
+
+// Aggregate run an aggregation pipeline over the index and decode the resulting rows into {out}.
+// {out} must be a pointer to a slice of structs or maps, the same rules used by Search apply.
+// Values that can not be decoded are only reported to DecodeOptions.Logger, use AggregateWithCursor to get them as warnings
+func (r *RediSearch) Aggregate(ctx stdContext.Context, opts AggregateOptions, out interface{}) (int64, error) {
+	if opts.IndexName == "" {
+		return 0, errors.New("missing required IndexName")
+	}
+	v, err := outSliceValue(out)
+	if err != nil {
+		return 0, err
+	}
+
+	do := r.client.Do(ctx, aggregateArgs(opts)...)
+	res, err := do.Result()
+	if err != nil {
+		return 0, err
+	}
+	total, rows, err := parseAggregateResults(res)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := decodeMaps(rows, nil, v, r.decode); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func aggregateArgs(opts AggregateOptions) []interface{} {
+	query := opts.Query
+	if query == "" {
+		query = "*"
+	}
+	args := []interface{}{
+		"FT.AGGREGATE",
+		opts.IndexName,
+		query,
+	}
+	if opts.Verbatim {
+		args = append(args, SearchFlagVerbatim)
+	}
+	for _, step := range opts.Steps {
+		args = append(args, step...)
+	}
+	return append(args, paramsArgs(opts.Params, opts.Dialect)...)
+}
+
+// parseAggregateResults into a list of maps, one per row.
+// Array values (ie: TOLIST reducer) are joined using a comma, the default TAG separator
+func parseAggregateResults(raw interface{}) (int64, []map[string]string, error) {
+	// [total [field1 value1 ...] [field1 value1 ...] ....]
+	resSlice, ok := raw.([]interface{})
+	if !ok {
+		return 0, nil, fmt.Errorf("invalid redis response type: %T", raw)
+	}
+	if len(resSlice) == 0 {
+		return 0, nil, nil
+	}
+	total, ok := resSlice[0].(int64)
+	if !ok {
+		return 0, nil, fmt.Errorf("invalid redis response.total type: %T", resSlice[0])
+	}
+
+	rows := make([]map[string]string, 0, len(resSlice)-1)
+	for _, rawRow := range resSlice[1:] {
+		row, ok := rawRow.([]interface{})
+		if !ok {
+			return 0, nil, fmt.Errorf("invalid redis response row type: %T", rawRow)
+		}
+		m := make(map[string]string, len(row)/2)
+		for j := 0; j+1 < len(row); j += 2 {
+			field, ok := row[j].(string)
+			if !ok {
+				return 0, nil, fmt.Errorf("invalid redis response field type: %T", row[j])
+			}
+			if row[j+1] == nil {
+				continue
+			}
+			m[field] = aggregateValueString(row[j+1])
+		}
+		rows = append(rows, m)
+	}
+	return total, rows, nil
+}
+
+func aggregateValueString(value interface{}) string {
+	switch val := value.(type) {
+	case string:
+		return val
+	case []interface{}:
+		items := make([]string, 0, len(val))
+		for _, item := range val {
+			if item != nil {
+				items = append(items, aggregateValueString(item))
+			}
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,144 @@
+package redisearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_aggregateArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts AggregateOptions
+		want []interface{}
+	}{
+		{
+			name: "ok: default query",
+			opts: AggregateOptions{IndexName: "idx"},
+			want: []interface{}{"FT.AGGREGATE", "idx", "*"},
+		},
+		{
+			name: "ok: full pipeline",
+			opts: AggregateOptions{
+				IndexName: "idx",
+				Query:     "@country:{colombia}",
+				Verbatim:  true,
+				Steps: []AggregateStep{
+					AggregateLoad("name", "@population"),
+					AggregateGroupBy([]string{"tags"},
+						ReducerCount("count"),
+						ReducerSum("population", "total"),
+						ReducerQuantile("population", 0.5, "median"),
+						ReducerFirstValue("name", &SortBy{FieldName: "population", Descending: true}, "biggest"),
+					),
+					AggregateApply("@total/@count", "avg"),
+					AggregateFilter("@count > 1"),
+					AggregateSortBy(10, SortBy{FieldName: "count", Descending: true}, SortBy{FieldName: "tags"}),
+					AggregateLimit(0, 5),
+				},
+			},
+			want: []interface{}{
+				"FT.AGGREGATE", "idx", "@country:{colombia}", "VERBATIM",
+				"LOAD", 2, "@name", "@population",
+				"GROUPBY", 1, "@tags",
+				"REDUCE", "COUNT", 0, "AS", "count",
+				"REDUCE", "SUM", 1, "@population", "AS", "total",
+				"REDUCE", "QUANTILE", 2, "@population", "0.5", "AS", "median",
+				"REDUCE", "FIRST_VALUE", 4, "@name", "BY", "@population", "DESC", "AS", "biggest",
+				"APPLY", "@total/@count", "AS", "avg",
+				"FILTER", "@count > 1",
+				"SORTBY", 4, "@count", "DESC", "@tags", "ASC", "MAX", 10,
+				"LIMIT", 0, 5,
+			},
+		},
+		{
+			name: "ok: load all fields",
+			opts: AggregateOptions{
+				IndexName: "idx",
+				Steps:     []AggregateStep{AggregateLoad()},
+			},
+			want: []interface{}{"FT.AGGREGATE", "idx", "*", "LOAD", "*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := aggregateArgs(tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aggregateArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseAggregateResults(t *testing.T) {
+	type Row struct {
+		Tags  string  `json:"tags"`
+		Count int     `json:"count"`
+		Avg   float64 `json:"avg"`
+		Names string  `json:"names"`
+	}
+	tests := []struct {
+		name      string
+		raw       interface{}
+		out       interface{}
+		wantTotal int64
+		wantOut   interface{}
+		wantErr   bool
+	}{
+		{
+			name: "ok: struct rows",
+			raw: []interface{}{
+				int64(2),
+				[]interface{}{"tags", "cauca", "count", "3", "avg", "1.5", "names", []interface{}{"a", "b"}},
+				[]interface{}{"tags", "valle", "count", "1", "avg", nil},
+			},
+			out:       &[]Row{},
+			wantTotal: 2,
+			wantOut: &[]Row{
+				{Tags: "cauca", Count: 3, Avg: 1.5, Names: "a,b"},
+				{Tags: "valle", Count: 1},
+			},
+		},
+		{
+			name: "ok: map rows",
+			raw: []interface{}{
+				int64(1),
+				[]interface{}{"tags", "cauca", "count", "3"},
+			},
+			out:       &[]map[string]string{},
+			wantTotal: 1,
+			wantOut:   &[]map[string]string{{"tags": "cauca", "count": "3"}},
+		},
+		{
+			name:      "ok: no rows",
+			raw:       []interface{}{int64(0)},
+			out:       &[]Row{},
+			wantTotal: 0,
+			wantOut:   &[]Row{},
+		},
+		{
+			name:    "invalid row type",
+			raw:     []interface{}{int64(1), "row"},
+			out:     &[]Row{},
+			wantOut: &[]Row{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, rows, err := parseAggregateResults(tt.raw)
+			if err == nil {
+				v, _ := outSliceValue(tt.out)
+				_, err = decodeMaps(rows, nil, v, DecodeOptions{})
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseAggregateResults() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if total != tt.wantTotal {
+				t.Errorf("parseAggregateResults() got total = %v, want %v", total, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(tt.out, tt.wantOut) {
+				t.Errorf("parseAggregateResults() out = %+v, want %+v", tt.out, tt.wantOut)
+			}
+		})
+	}
+}
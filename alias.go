@@ -0,0 +1,55 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+)
+
+// AliasAdd add an alias to the given index. Aliases can be used instead of the index name in Search, Aggregate, IndexExists,
+// IndexInfo and other read commands, so the physical index can be replaced without changing the application code.
+// DropIndex rejects aliases
+func (r *RediSearch) AliasAdd(ctx stdContext.Context, alias, index string) error {
+	if alias == "" || index == "" {
+		return errors.New("missing required alias or index name")
+	}
+	return r.client.Do(ctx, "FT.ALIASADD", alias, index).Err()
+}
+
+// AliasUpdate point the alias to the given index. The alias is created if it does not exist
+func (r *RediSearch) AliasUpdate(ctx stdContext.Context, alias, index string) error {
+	if alias == "" || index == "" {
+		return errors.New("missing required alias or index name")
+	}
+	return r.client.Do(ctx, "FT.ALIASUPDATE", alias, index).Err()
+}
+
+// AliasDel remove the alias. The index it points to is not affected
+func (r *RediSearch) AliasDel(ctx stdContext.Context, alias string) error {
+	if alias == "" {
+		return errors.New("missing required alias")
+	}
+	return r.client.Do(ctx, "FT.ALIASDEL", alias).Err()
+}
+
+// resolveIndexName return the physical index name of {name}, which can be an index or an alias
+func (r *RediSearch) resolveIndexName(ctx stdContext.Context, name string) (string, error) {
+	do := r.client.Do(ctx, "FT.INFO", name)
+	raw, err := do.Result()
+	if err != nil {
+		return "", err
+	}
+	info, err := infoMap(raw)
+	if err != nil {
+		return "", err
+	}
+	if index := infoString(info["index_name"]); index != "" {
+		return index, nil
+	}
+	return name, nil
+}
+
+// aliasDropError returned when an index is dropped using one of its aliases
+func aliasDropError(alias, index string) error {
+	return fmt.Errorf("%s is an alias of index %s, drop the index by its name or remove the alias using AliasDel", alias, index)
+}
@@ -0,0 +1,96 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newAliasTestClient return a client where "cities" is an alias of the index "cities_v1" and "towns" does not exist
+func newAliasTestClient() (*RediSearch, *testRecorder) {
+	return newTestClient(func(args []interface{}) (interface{}, error) {
+		if args[0] != "FT.INFO" {
+			return "OK", nil
+		}
+		switch args[1] {
+		case "cities", "cities_v1":
+			return []interface{}{"index_name", "cities_v1"}, nil
+		}
+		return nil, errors.New("Unknown Index name")
+	})
+}
+
+func TestRediSearch_DropIndex(t *testing.T) {
+	ctx := context.Background()
+	r, rec := newAliasTestClient()
+	if err := r.DropIndex(ctx, "cities", false); err == nil {
+		t.Error("DropIndex() expected error for alias")
+	}
+	if err := r.DropIndex(ctx, "cities_v1", true); err != nil {
+		t.Errorf("DropIndex() error = %v", err)
+	}
+	want := [][]interface{}{
+		{"FT.INFO", "cities"},
+		{"FT.INFO", "cities_v1"},
+		{"FT.DROPINDEX", "cities_v1", "DD"},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DropIndex() commands = %v, want %v", got, want)
+	}
+}
+
+func TestRediSearch_CreateIndex_dropIfExists(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		index        string
+		dropIfExists bool
+		want         [][]interface{}
+		wantErr      bool
+	}{
+		{
+			name:         "ok: drop existing index",
+			index:        "cities_v1",
+			dropIfExists: true,
+			want: [][]interface{}{
+				{"FT.INFO", "cities_v1"},
+				{"FT.DROPINDEX", "cities_v1"},
+				{"FT.CREATE", "cities_v1", "ON", IndexOnHash},
+			},
+		},
+		{
+			name:  "ok: new index",
+			index: "towns",
+			want: [][]interface{}{
+				{"FT.INFO", "towns"},
+				{"FT.CREATE", "towns", "ON", IndexOnHash},
+			},
+		},
+		{
+			name:    "index exists",
+			index:   "cities_v1",
+			want:    [][]interface{}{{"FT.INFO", "cities_v1"}},
+			wantErr: true,
+		},
+		{
+			name:         "alias",
+			index:        "cities",
+			dropIfExists: true,
+			want:         [][]interface{}{{"FT.INFO", "cities"}},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, rec := newAliasTestClient()
+			err := r.CreateIndex(ctx, IndexOptions{IndexName: tt.index}, tt.dropIfExists)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := rec.commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateIndex() commands = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
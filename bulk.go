@@ -0,0 +1,197 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Doc a document to be stored using PutMany
+type Doc struct {
+	Key string
+	// Value map or struct, see Put
+	Value interface{}
+}
+
+type BulkOptions struct {
+	// ChunkSize number of documents (or keys) sent per round trip. Defaults to 500
+	ChunkSize int
+	// Atomic send each chunk as a MULTI/EXEC transaction instead of a plain pipeline.
+	// On cluster deployments transactions can not span hash slots, so each chunk is split in one transaction per slot
+	Atomic bool
+	// Override delete previous documents to create fresh ones only with the values provided (see Put). Ignored by DeleteMany.
+	// Chunks are always sent as transactions when set, so documents are never seen missing
+	Override bool
+	// Concurrency maximum number of chunks sent at the same time. Defaults to 1 (chunks are sent sequentially)
+	Concurrency int
+}
+
+// BulkError the keys that could not be written or deleted by PutMany or DeleteMany, along with the error of each one.
+// Keys not present were processed successfully
+type BulkError struct {
+	Errors map[string]error
+}
+
+func (e *BulkError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) > 3 {
+		keys = append(keys[:3], "...")
+	}
+	return fmt.Sprintf("%d keys failed: %s", len(e.Errors), strings.Join(keys, ", "))
+}
+
+// PutMany store multiple documents using pipelines (or transactions), one round trip per chunk of documents.
+// docs: []Doc or map[string]interface{} (key -> map or struct, see Put).
+// Documents that can not be encoded are skipped, if any document fails a *BulkError is returned with the error of each failed key
+func (r *RediSearch) PutMany(ctx stdContext.Context, docs interface{}, opts BulkOptions) error {
+	var list []Doc
+	switch d := docs.(type) {
+	case []Doc:
+		list = d
+	case map[string]interface{}:
+		list = make([]Doc, 0, len(d))
+		for key, value := range d {
+			list = append(list, Doc{Key: key, Value: value})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	default:
+		return errors.New("{docs} arg must be of type []Doc or map[string]interface{}")
+	}
+
+	errs := newBulkErrors()
+	runChunks(len(list), opts, func(start, end int) {
+		var keys []string
+		var values [][]interface{}
+		for _, doc := range list[start:end] {
+			if doc.Key == "" || doc.Value == nil {
+				errs.add(doc.Key, errors.New("invalid key or nil value"))
+				continue
+			}
+			docValues, err := encodeHash(doc.Value)
+			if err != nil {
+				errs.add(doc.Key, err)
+				continue
+			}
+			keys = append(keys, doc.Key)
+			values = append(values, docValues)
+		}
+		if len(keys) == 0 {
+			return
+		}
+
+		var cmdKeys []string
+		var cmds []redis.Cmder
+		pipe := r.pipeline(opts.Atomic || opts.Override)
+		for i, key := range keys {
+			if opts.Override {
+				cmdKeys = append(cmdKeys, key)
+				cmds = append(cmds, pipe.Del(ctx, key))
+			}
+			cmdKeys = append(cmdKeys, key)
+			cmds = append(cmds, pipe.HSet(ctx, key, values[i]...))
+		}
+		_, _ = pipe.Exec(ctx)
+		for i, cmd := range cmds {
+			if err := cmd.Err(); err != nil {
+				errs.add(cmdKeys[i], err)
+			}
+		}
+	})
+	return errs.err()
+}
+
+// DeleteMany delete multiple documents using pipelines (or transactions), one round trip per chunk of keys.
+// Keys are grouped by hash slot within each chunk, so a single DEL is sent per slot.
+// If any key fails a *BulkError is returned with the error of each failed key
+func (r *RediSearch) DeleteMany(ctx stdContext.Context, keys []string, opts BulkOptions) error {
+	errs := newBulkErrors()
+	runChunks(len(keys), opts, func(start, end int) {
+		batches := slotBatches(keys[start:end])
+		cmds := make([]redis.Cmder, len(batches))
+		pipe := r.pipeline(opts.Atomic)
+		for i, batch := range batches {
+			cmds[i] = pipe.Del(ctx, batch...)
+		}
+		_, _ = pipe.Exec(ctx)
+		for i, cmd := range cmds {
+			if err := cmd.Err(); err != nil {
+				for _, key := range batches[i] {
+					errs.add(key, err)
+				}
+			}
+		}
+	})
+	return errs.err()
+}
+
+func (r *RediSearch) pipeline(atomic bool) redis.Pipeliner {
+	if atomic {
+		return r.client.TxPipeline()
+	}
+	return r.client.Pipeline()
+}
+
+// runChunks call fn with the bounds of each chunk of {n} items, running up to opts.Concurrency chunks at the same time
+func runChunks(n int, opts BulkOptions, fn func(start, end int)) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 500
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for start := 0; start < n; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(start, end int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// bulkErrors collect per key errors from concurrent chunks
+type bulkErrors struct {
+	mu     sync.Mutex
+	errors map[string]error
+}
+
+func newBulkErrors() *bulkErrors {
+	return &bulkErrors{errors: make(map[string]error)}
+}
+
+// add the error of the key, the first error of each key is kept
+func (b *bulkErrors) add(key string, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.errors[key]; !ok {
+		b.errors[key] = err
+	}
+}
+
+func (b *bulkErrors) err() error {
+	if len(b.errors) == 0 {
+		return nil
+	}
+	return &BulkError{Errors: b.errors}
+}
@@ -0,0 +1,148 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_runChunks(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		opts BulkOptions
+		want [][2]int
+	}{
+		{
+			name: "default chunk size",
+			n:    1200,
+			want: [][2]int{{0, 500}, {500, 1000}, {1000, 1200}},
+		},
+		{
+			name: "concurrent",
+			n:    5,
+			opts: BulkOptions{ChunkSize: 2, Concurrency: 3},
+			want: [][2]int{{0, 2}, {2, 4}, {4, 5}},
+		},
+		{
+			name: "empty",
+			n:    0,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got [][2]int
+			runChunks(tt.n, tt.opts, func(start, end int) {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, [2]int{start, end})
+			})
+			sort.Slice(got, func(i, j int) bool { return got[i][0] < got[j][0] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runChunks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runChunks_concurrency(t *testing.T) {
+	var running, max int32
+	runChunks(20, BulkOptions{ChunkSize: 1, Concurrency: 3}, func(start, end int) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			prev := atomic.LoadInt32(&max)
+			if current <= prev || atomic.CompareAndSwapInt32(&max, prev, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	})
+	if max > 3 {
+		t.Errorf("runChunks() ran %d chunks at the same time, want at most 3", max)
+	}
+}
+
+func TestBulkError_Error(t *testing.T) {
+	err := &BulkError{Errors: map[string]error{
+		"doc:4": errors.New("fail"),
+		"doc:1": errors.New("fail"),
+		"doc:3": errors.New("fail"),
+		"doc:2": errors.New("fail"),
+	}}
+	want := "4 keys failed: doc:1, doc:2, doc:3, ..."
+	if got := err.Error(); got != want {
+		t.Errorf("BulkError.Error() = %v, want %v", got, want)
+	}
+}
+
+func TestRediSearch_PutMany_invalid(t *testing.T) {
+	r := &RediSearch{}
+	if err := r.PutMany(context.Background(), []string{"doc:1"}, BulkOptions{}); err == nil {
+		t.Errorf("PutMany() expected error for invalid docs type")
+	}
+
+	err := r.PutMany(context.Background(), []Doc{{Key: "doc:1"}, {Key: "doc:2", Value: 1}}, BulkOptions{})
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 2 {
+		t.Errorf("PutMany() error = %v, want BulkError for doc:1 and doc:2", err)
+	}
+}
+
+func TestRediSearch_PutMany(t *testing.T) {
+	failed := errors.New("chunk failed")
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		if args[1] == "doc:3" {
+			return nil, failed
+		}
+		return int64(1), nil
+	})
+	docs := map[string]interface{}{
+		"doc:1": map[string]string{"name": "a"},
+		"doc:2": map[string]string{"name": "b"},
+		"doc:3": map[string]string{"name": "c"},
+	}
+	err := r.PutMany(context.Background(), docs, BulkOptions{ChunkSize: 2, Override: true})
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 1 || !errors.Is(bulkErr.Errors["doc:3"], failed) {
+		t.Fatalf("PutMany() error = %v, want BulkError for doc:3", err)
+	}
+	want := [][]interface{}{
+		{"multi"}, {"del", "doc:1"}, {"hset", "doc:1", "name", "a"}, {"del", "doc:2"}, {"hset", "doc:2", "name", "b"}, {"exec"},
+		{"multi"}, {"del", "doc:3"}, {"hset", "doc:3", "name", "c"}, {"exec"},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PutMany() commands = %v, want %v", got, want)
+	}
+}
+
+func TestRediSearch_DeleteMany(t *testing.T) {
+	failed := errors.New("chunk failed")
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		if args[1] == "{b}1" {
+			return nil, failed
+		}
+		return int64(len(args) - 1), nil
+	})
+	err := r.DeleteMany(context.Background(), []string{"{a}1", "{b}1", "{a}2", "{b}2", "{a}3"}, BulkOptions{ChunkSize: 3})
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) || len(bulkErr.Errors) != 1 || !errors.Is(bulkErr.Errors["{b}1"], failed) {
+		t.Fatalf("DeleteMany() error = %v, want BulkError for {b}1", err)
+	}
+	want := [][]interface{}{
+		// chunk 1: {a}1 {b}1 {a}2
+		{"del", "{a}1", "{a}2"}, {"del", "{b}1"},
+		// chunk 2: {b}2 {a}3
+		{"del", "{b}2"}, {"del", "{a}3"},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeleteMany() commands = %v, want %v", got, want)
+	}
+}
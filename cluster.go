@@ -0,0 +1,59 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"github.com/redis/go-redis/v9"
+	"strings"
+)
+
+// clusterSlots number of hash slots of a redis cluster
+const clusterSlots = 16384
+
+// doKey send the command routing it (on cluster deployments) by the argument at position {keyPos}.
+// go-redis routes by the first argument, which is not the index name or key of every command (ie: FT.CURSOR READ {index} {id})
+func (r *RediSearch) doKey(ctx stdContext.Context, keyPos int, args ...interface{}) *redis.Cmd {
+	cmd := redis.NewCmd(ctx, args...)
+	cmd.SetFirstKeyPos(int8(keyPos))
+	_ = r.client.Process(ctx, cmd)
+	return cmd
+}
+
+// keySlot return the cluster hash slot of the key (CRC16 of the key or its {hash tag}, modulo 16384)
+func keySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return int(crc) % clusterSlots
+}
+
+// slotBatches group the keys by hash slot, so multi-key commands and transactions never mix slots (CROSSSLOT errors on cluster deployments).
+// Slots are returned in order of appearance and keys keep their order within each slot. Callers bound the batch size by chunking {keys} (see BulkOptions.ChunkSize)
+func slotBatches(keys []string) [][]string {
+	var slots []int
+	bySlot := make(map[int][]string)
+	for _, key := range keys {
+		slot := keySlot(key)
+		if _, ok := bySlot[slot]; !ok {
+			slots = append(slots, slot)
+		}
+		bySlot[slot] = append(bySlot[slot], key)
+	}
+	var batches [][]string
+	for _, slot := range slots {
+		batches = append(batches, bySlot[slot])
+	}
+	return batches
+}
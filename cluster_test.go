@@ -0,0 +1,69 @@
+package redisearch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_keySlot(t *testing.T) {
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{key: "123456789", want: 12739},
+		{key: "foo", want: 12182},
+		{key: "{foo}:bar", want: 12182},
+		{key: "bar:{foo}", want: 12182},
+		{key: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := keySlot(tt.key); got != tt.want {
+				t.Errorf("keySlot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if keySlot("{}foo") == keySlot("foo") {
+		t.Errorf("keySlot() empty hash tags must use the whole key")
+	}
+}
+
+func Test_slotBatches(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want [][]string
+	}{
+		{
+			name: "group by slot",
+			keys: []string{"{a}1", "{b}1", "{a}2", "{b}2", "{a}3"},
+			want: [][]string{{"{a}1", "{a}2", "{a}3"}, {"{b}1", "{b}2"}},
+		},
+		{
+			name: "empty",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slotBatches(tt.keys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slotBatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRediSearch_Delete(t *testing.T) {
+	ctx := context.Background()
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		return int64(1), nil
+	})
+	if err := r.Delete(ctx, "{a}1"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+	want := [][]interface{}{{"del", "{a}1"}}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Delete() commands = %v, want %v", got, want)
+	}
+}
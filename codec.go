@@ -0,0 +1,414 @@
+package redisearch
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TimeFormatRFC3339 store time.Time values as RFC3339 strings with nanoseconds (default)
+	TimeFormatRFC3339 = "rfc3339"
+	// TimeFormatUnix store time.Time values as unix timestamps in seconds, so they can be indexed as NUMERIC
+	TimeFormatUnix = "unix"
+	// TimeFormatUnixMilli store time.Time values as unix timestamps in milliseconds, so they can be indexed as NUMERIC
+	TimeFormatUnixMilli = "unixmilli"
+)
+
+// CodecOptions encoding options of a struct field, taken from its redisearch tag (ie: `redisearch:"tag,separator=;"` or `redisearch:",time=unix"`).
+// Map values use the default options
+type CodecOptions struct {
+	// Separator used to join and split string slices (separator={character}). Defaults to "," the default TAG separator
+	Separator string
+	// TimeFormat see TimeFormat* constants (time={format}). Defaults to TimeFormatRFC3339
+	TimeFormat string
+}
+
+// Encoder encode a value into the string stored in the hash field
+type Encoder func(v reflect.Value, opts CodecOptions) (string, error)
+
+// Decoder decode the hash field value {s} into {v}, which is always settable
+type Decoder func(s string, v reflect.Value, opts CodecOptions) error
+
+type codec struct {
+	encoder Encoder
+	decoder Decoder
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+	// errUnsupportedType returned by encodeValue and decodeValue when the value type can not be stored in a hash field
+	errUnsupportedType = errors.New("unsupported type")
+
+	// builtinCodecs codecs used when no encoder or decoder was registered for the type (see RegisterCodec)
+	builtinCodecs = map[reflect.Type]codec{
+		timeType: {encoder: encodeTime, decoder: decodeTime},
+	}
+
+	codecsMu sync.RWMutex
+	codecs   = map[reflect.Type]codec{}
+	// codecsGeneration incremented every time a codec is registered, see fieldCacheKey
+	codecsGeneration uint64
+)
+
+// RegisterCodec set the encoder and decoder used for values of type {t} by Put and Search (and any other method storing or
+// decoding documents), replacing the built-in handling of the type. A nil encoder or decoder keeps the built-in handling for that direction.
+// Built-in handling, in order of precedence:
+// * time.Time: see CodecOptions.TimeFormat
+// * pointers: nil pointers are not stored, pointers are allocated when the field is present
+// * encoding.TextMarshaler / encoding.TextUnmarshaler
+// * []float32 / []float64: vector blobs
+// * []byte: stored as is
+// * string slices: joined using CodecOptions.Separator
+// * string, bool, int, uint (including uintptr) and float kinds, values out of the range of the kind are rejected on decode
+func RegisterCodec(t reflect.Type, encoder Encoder, decoder Decoder) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[t] = codec{encoder: encoder, decoder: decoder}
+	// registered types are no longer flattened (see isValueStruct)
+	codecsGeneration++
+	clearFieldCache()
+}
+
+// codecsVersion return the number of codecs registered so far
+func codecsVersion() uint64 {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	return codecsGeneration
+}
+
+// lookupCodec return the codec of the type, the built-in encoder or decoder is used for each direction not registered
+func lookupCodec(t reflect.Type) codec {
+	codecsMu.RLock()
+	c := codecs[t]
+	codecsMu.RUnlock()
+	builtin := builtinCodecs[t]
+	if c.encoder == nil {
+		c.encoder = builtin.encoder
+	}
+	if c.decoder == nil {
+		c.decoder = builtin.decoder
+	}
+	return c
+}
+
+// fieldCodecOptions return the codec options set in the redisearch tag of the struct field
+func fieldCodecOptions(field reflect.StructField) CodecOptions {
+	var opts CodecOptions
+	parts := strings.Split(field.Tag.Get(tagName), ",")
+	for _, part := range parts[1:] {
+		option, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch strings.ToLower(option) {
+		case "separator":
+			opts.Separator = value
+		case "time":
+			opts.TimeFormat = strings.ToLower(value)
+		}
+	}
+	return opts
+}
+
+// encodeHash encode a map or struct into HSET field/value pairs. Struct and map values are encoded using the same rules (see encodeValue),
+// but unsupported struct fields are skipped (so structs can hold data that is not stored) while unsupported map values are rejected.
+// Nested structs are flattened in both cases (ie: address.city, see structFields), and so are nested maps of map documents
+func encodeHash(value interface{}) ([]interface{}, error) {
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Invalid {
+		return nil, errors.New("invalid {value} type")
+	}
+	if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Struct:
+		// structs passed by value are not addressable, pointer receiver methods (ie: MarshalText) could not be called otherwise
+		return encodeStruct(addressable(val))
+	case reflect.Map:
+		return encodeMap(val)
+	}
+	return nil, errors.New("{values} arg must be of type map or struct")
+}
+
+func encodeStruct(val reflect.Value) ([]interface{}, error) {
+	var values []interface{}
+	for _, field := range structFields(val.Type()) {
+		if field.id {
+			continue // the document id is the key itself
+		}
+		v, ok := fieldByIndex(val, field.index, false)
+		if !ok {
+			continue // nil nested struct
+		}
+		fieldValue, err := encodeValue(v, field.opts)
+		if errors.Is(err, errUnsupportedType) {
+			continue // ignore unsupported type
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.name, err)
+		}
+		if fieldValue != nil {
+			values = append(values, field.name, fieldValue)
+		}
+	}
+	return values, nil
+}
+
+// encodeMap encode a map with string keys. Keys are sorted so the command is deterministic, nil values are skipped.
+// Struct and map values are flattened using the nested separator, the same way nested struct fields are
+func encodeMap(val reflect.Value) ([]interface{}, error) {
+	if val.Type().Key().Kind() != reflect.String {
+		return nil, errors.New("map key must be of type string")
+	}
+	keys := make([]string, 0, val.Len())
+	for _, k := range val.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+
+	values := make([]interface{}, 0, len(keys)*2)
+	for _, k := range keys {
+		v := val.MapIndex(reflect.ValueOf(k).Convert(val.Type().Key()))
+		for v.Kind() == reflect.Interface && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Struct {
+			v = addressable(v)
+		}
+		if nested, ok := nestedValue(v); ok {
+			var nestedValues []interface{}
+			var err error
+			if nested.Kind() == reflect.Struct {
+				nestedValues, err = encodeStruct(nested)
+			} else {
+				nestedValues, err = encodeMap(nested)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", k, err)
+			}
+			for i := 0; i < len(nestedValues); i += 2 {
+				values = append(values, k+getNestedSeparator()+nestedValues[i].(string), nestedValues[i+1])
+			}
+			continue
+		}
+		fieldValue, err := encodeValue(v, CodecOptions{})
+		if errors.Is(err, errUnsupportedType) {
+			return nil, fmt.Errorf("unsupported value type for field %s: %s", k, v.Type())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", k, err)
+		}
+		if fieldValue != nil {
+			values = append(values, k, fieldValue)
+		}
+	}
+	return values, nil
+}
+
+// nestedValue return the struct or map held by {v} (dereferencing pointers) if it must be flattened instead of stored as a single value.
+// Structs handled by a codec or encoding.TextMarshaler (ie: time.Time) are single values
+func nestedValue(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return v, !isValueStruct(v.Type())
+	case reflect.Map:
+		return v, true
+	}
+	return v, false
+}
+
+// addressable return {v} or an addressable copy of it
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// encodeValue return the value to be stored in a hash field, nil if the field must not be stored (nil pointers and interfaces).
+// It returns errUnsupportedType if the value type is not supported, see RegisterCodec for the supported types
+func encodeValue(v reflect.Value, opts CodecOptions) (interface{}, error) {
+	if c := lookupCodec(v.Type()); c.encoder != nil {
+		return c.encoder(v, opts)
+	}
+	switch {
+	case v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encodeValue(v.Elem(), opts)
+	case v.Type().Implements(textMarshalerType):
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	case v.CanAddr() && v.Addr().Type().Implements(textMarshalerType):
+		text, err := v.Addr().Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	if blob, ok := vectorBlob(v); ok {
+		return blob, nil
+	}
+	if v.Kind() == reflect.Slice {
+		switch v.Type().Elem().Kind() {
+		case reflect.Uint8:
+			return v.Bytes(), nil
+		case reflect.String:
+			items := make([]string, v.Len())
+			for i := range items {
+				items[i] = v.Index(i).String()
+			}
+			return strings.Join(items, separator(opts)), nil
+		}
+	}
+	// scalars are formatted by kind, go-redis rejects named types (ie: type Status string) and uintptr
+	switch kind := v.Kind(); {
+	case kind == reflect.String:
+		return v.String(), nil
+	case kind == reflect.Bool:
+		// "1" and "0", as go-redis writes bool values
+		if v.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()), nil
+	}
+	return nil, errUnsupportedType
+}
+
+// decodeValue decode the hash field value {s} into {v} (settable), the reverse of encodeValue.
+// It returns errUnsupportedType if the value type is not supported, or the parsing error if {s} is not valid for the type
+func decodeValue(s string, v reflect.Value, opts CodecOptions) error {
+	if c := lookupCodec(v.Type()); c.decoder != nil {
+		return c.decoder(s, v, opts)
+	}
+	if v.Kind() == reflect.Ptr {
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeValue(s, elem.Elem(), opts); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if ok, err := setVectorFromBlob(v, s); ok {
+		return err
+	}
+	if v.Kind() == reflect.Slice {
+		switch v.Type().Elem().Kind() {
+		case reflect.Uint8:
+			v.SetBytes([]byte(s))
+			return nil
+		case reflect.String:
+			var items []string
+			if s != "" {
+				items = strings.Split(s, separator(opts))
+			}
+			slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+			for i, item := range items {
+				slice.Index(i).SetString(item)
+			}
+			v.Set(slice)
+			return nil
+		}
+	}
+	if _, ok := supportedDataTypes[v.Kind()]; !ok {
+		return errUnsupportedType
+	}
+	switch kind := v.Kind(); {
+	case kind == reflect.String:
+		v.SetString(s)
+	case kind >= reflect.Int && kind <= reflect.Int64:
+		// the bit size of the kind makes ParseInt report values out of range (ie: 300 into an int8) instead of truncating them
+		vInt, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(vInt)
+	case kind >= reflect.Uint && kind <= reflect.Uintptr:
+		// ParseUint rejects negative values instead of wrapping them
+		vUint, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(vUint)
+	case kind >= reflect.Float32 && kind <= reflect.Float64:
+		vFloat, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(vFloat)
+	case kind == reflect.Bool:
+		vBool, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(vBool)
+	}
+	return nil
+}
+
+func separator(opts CodecOptions) string {
+	if opts.Separator == "" {
+		return ","
+	}
+	return opts.Separator
+}
+
+func encodeTime(v reflect.Value, opts CodecOptions) (string, error) {
+	t := v.Interface().(time.Time)
+	switch opts.TimeFormat {
+	case TimeFormatUnix:
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case TimeFormatUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	case "", TimeFormatRFC3339:
+		return t.Format(time.RFC3339Nano), nil
+	}
+	return "", fmt.Errorf("unsupported time format %q", opts.TimeFormat)
+}
+
+func decodeTime(s string, v reflect.Value, opts CodecOptions) error {
+	var t time.Time
+	switch opts.TimeFormat {
+	case TimeFormatUnix, TimeFormatUnixMilli:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		t = time.Unix(i, 0).UTC()
+		if opts.TimeFormat == TimeFormatUnixMilli {
+			t = time.UnixMilli(i).UTC()
+		}
+	case "", TimeFormatRFC3339:
+		var err error
+		if t, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported time format %q", opts.TimeFormat)
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
@@ -0,0 +1,346 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_encodeHash(t *testing.T) {
+	type name string
+	type doc struct {
+		ID         string `redisearch:"id"`
+		Name       string `json:"name"`
+		Population int
+		Ignored    string `json:"-"`
+		Nested     struct{ A string }
+		internal   string
+	}
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    []interface{}
+		wantErr bool
+	}{
+		{
+			name:  "struct",
+			value: &doc{ID: "city:1", Name: "Popayan", Population: 320000, Ignored: "x", internal: "x"},
+			want:  []interface{}{"name", "Popayan", "Population", "320000", "Nested.A", ""},
+		},
+		{
+			name:  "map of strings",
+			value: map[string]string{"name": "Popayan", "country": "Colombia"},
+			want:  []interface{}{"country", "Colombia", "name", "Popayan"},
+		},
+		{
+			name:  "map of interfaces",
+			value: map[string]interface{}{"name": "Popayan", "population": 320000, "capital": true, "empty": nil},
+			want:  []interface{}{"capital", "1", "name", "Popayan", "population", "320000"},
+		},
+		{
+			name:  "map of vectors",
+			value: map[string]interface{}{"embedding": []float32{1}},
+			want:  []interface{}{"embedding", []byte{0x00, 0x00, 0x80, 0x3f}},
+		},
+		{
+			name:  "named key type",
+			value: map[name]int{"population": 320000},
+			want:  []interface{}{"population", "320000"},
+		},
+		{
+			name:  "nested map value",
+			value: map[string]interface{}{"location": map[string]float64{"lat": 2.4}},
+			want:  []interface{}{"location.lat", "2.4"},
+		},
+		{
+			name:  "nested struct value",
+			value: map[string]interface{}{"doc": &doc{Name: "Popayan"}, "code": testPtrText{"cau"}},
+			want:  []interface{}{"code", "CAU", "doc.name", "Popayan", "doc.Population", "0", "doc.Nested.A", ""},
+		},
+		{
+			name:  "struct by value with pointer receiver TextMarshaler",
+			value: struct{ Code testPtrText }{testPtrText{"cau"}},
+			want:  []interface{}{"Code", "CAU"},
+		},
+		{
+			name:    "nested map with non string keys",
+			value:   map[string]interface{}{"location": map[int]float64{1: 2.4}},
+			wantErr: true,
+		},
+		{
+			name:    "non string keys",
+			value:   map[int]string{1: "Popayan"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported value",
+			value:   []string{"Popayan"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeHash(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("encodeHash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type testStatus string
+
+type testCount int
+
+func TestRediSearch_Put_wire(t *testing.T) {
+	type doc struct {
+		Status testStatus `json:"status"`
+		Count  testCount  `json:"count"`
+		Handle uintptr    `json:"handle"`
+		Active bool       `json:"active"`
+		Ratio  float32    `json:"ratio"`
+	}
+	r, srv := newWireClient()
+	ctx := stdContext.Background()
+	if err := r.Put(ctx, "doc:1", doc{Status: "active", Count: 3, Handle: 7, Active: true, Ratio: 0.1}, false); err != nil {
+		t.Fatalf("Put() struct error = %v", err)
+	}
+	if err := r.Put(ctx, "doc:2", map[string]testStatus{"status": "closed"}, false); err != nil {
+		t.Fatalf("Put() map error = %v", err)
+	}
+	want := [][]string{
+		{"hset", "doc:1", "status", "active", "count", "3", "handle", "7", "active", "1", "ratio", "0.1"},
+		{"hset", "doc:2", "status", "closed"},
+	}
+	if got := srv.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Put() wire commands = %v, want %v", got, want)
+	}
+}
+
+// testPtrText implements encoding.TextMarshaler with a pointer receiver
+type testPtrText struct {
+	code string
+}
+
+func (t *testPtrText) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(t.code)), nil
+}
+
+type testCents int64
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec(reflect.TypeOf(testCents(0)),
+		func(v reflect.Value, opts CodecOptions) (string, error) {
+			return strconv.FormatFloat(float64(v.Int())/100, 'f', 2, 64), nil
+		},
+		func(s string, v reflect.Value, opts CodecOptions) error {
+			f, err := strconv.ParseFloat(s, 64)
+			v.SetInt(int64(math.Round(f * 100)))
+			return err
+		},
+	)
+	type product struct {
+		Price testCents
+	}
+	values, err := encodeHash(product{Price: 1999})
+	if err != nil {
+		t.Fatalf("encodeHash() error = %v", err)
+	}
+	if want := []interface{}{"Price", "19.99"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("encodeHash() = %v, want %v", values, want)
+	}
+	var out []product
+	if _, err := decodeMaps([]map[string]string{{"Price": "5.10"}}, nil, reflect.ValueOf(&out), DecodeOptions{}); err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	if out[0].Price != 510 {
+		t.Errorf("decodeMaps() = %v, want 510", out[0].Price)
+	}
+}
+
+func Test_codec_builtins(t *testing.T) {
+	type doc struct {
+		Created  time.Time  `json:"created"`
+		Updated  time.Time  `json:"updated" redisearch:"numeric,time=unix"`
+		Seen     *time.Time `json:"seen" redisearch:",time=unixmilli"`
+		Count    *int       `json:"count"`
+		Missing  *int       `json:"missing"`
+		Tags     []string   `json:"tags" redisearch:"tag,separator=;"`
+		Colors   []string   `json:"colors"`
+		IP       net.IP     `json:"ip"`
+		Raw      []byte     `json:"raw"`
+		Duration time.Duration
+	}
+	created := time.Date(2023, 5, 1, 10, 30, 0, 123, time.UTC)
+	updated := time.Unix(1682937000, 0)
+	seen := time.UnixMilli(1682937000123)
+	count := 3
+	in := doc{
+		Created:  created,
+		Updated:  updated,
+		Seen:     &seen,
+		Count:    &count,
+		Tags:     []string{"a", "b"},
+		Colors:   []string{"red", "blue"},
+		IP:       net.ParseIP("10.0.0.1"),
+		Raw:      []byte{0, 1, 2},
+		Duration: time.Second,
+	}
+
+	values, err := encodeHash(in)
+	if err != nil {
+		t.Fatalf("encodeHash() error = %v", err)
+	}
+	wantValues := []interface{}{
+		"created", "2023-05-01T10:30:00.000000123Z",
+		"updated", "1682937000",
+		"seen", "1682937000123",
+		"count", "3",
+		"tags", "a;b",
+		"colors", "red,blue",
+		"ip", "10.0.0.1",
+		"raw", []byte{0, 1, 2},
+		"Duration", "1000000000",
+	}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Fatalf("encodeHash() = %v, want %v", values, wantValues)
+	}
+
+	m := make(map[string]string, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		switch v := values[i+1].(type) {
+		case []byte:
+			m[values[i].(string)] = string(v)
+		default:
+			m[values[i].(string)] = fmt.Sprint(v)
+		}
+	}
+	var out []doc
+	if _, err := decodeMaps([]map[string]string{m}, nil, reflect.ValueOf(&out), DecodeOptions{}); err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	got := out[0]
+	if !got.Created.Equal(created) || !got.Updated.Equal(updated) || got.Seen == nil || !got.Seen.Equal(seen) {
+		t.Errorf("decodeMaps() times = %v %v %v", got.Created, got.Updated, got.Seen)
+	}
+	if got.Count == nil || *got.Count != 3 || got.Missing != nil {
+		t.Errorf("decodeMaps() pointers = %v %v", got.Count, got.Missing)
+	}
+	if !reflect.DeepEqual(got.Tags, in.Tags) || !reflect.DeepEqual(got.Colors, in.Colors) {
+		t.Errorf("decodeMaps() slices = %v %v", got.Tags, got.Colors)
+	}
+	if !got.IP.Equal(in.IP) || !reflect.DeepEqual(got.Raw, in.Raw) || got.Duration != time.Second {
+		t.Errorf("decodeMaps() = %+v", got)
+	}
+}
+
+func Test_decodeValue_numbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		ptr     interface{}
+		want    interface{}
+		wantErr error
+	}{
+		{name: "int", value: "-42", ptr: new(int), want: -42},
+		{name: "int8 max", value: "127", ptr: new(int8), want: int8(127)},
+		{name: "int8 overflow", value: "128", ptr: new(int8), wantErr: strconv.ErrRange},
+		{name: "int16 underflow", value: "-32769", ptr: new(int16), wantErr: strconv.ErrRange},
+		{name: "int32 overflow", value: "2147483648", ptr: new(int32), wantErr: strconv.ErrRange},
+		{name: "int64 min", value: "-9223372036854775808", ptr: new(int64), want: int64(math.MinInt64)},
+		{name: "uint", value: "42", ptr: new(uint), want: uint(42)},
+		{name: "uint negative", value: "-1", ptr: new(uint), wantErr: strconv.ErrSyntax},
+		{name: "uint8 overflow", value: "256", ptr: new(uint8), wantErr: strconv.ErrRange},
+		{name: "uint32 max", value: "4294967295", ptr: new(uint32), want: uint32(math.MaxUint32)},
+		{name: "uint64 above MaxInt64", value: "18446744073709551615", ptr: new(uint64), want: uint64(math.MaxUint64)},
+		{name: "uint64 overflow", value: "18446744073709551616", ptr: new(uint64), wantErr: strconv.ErrRange},
+		{name: "uintptr", value: "4096", ptr: new(uintptr), want: uintptr(4096)},
+		{name: "uintptr negative", value: "-4096", ptr: new(uintptr), wantErr: strconv.ErrSyntax},
+		{name: "float32 overflow", value: "1e39", ptr: new(float32), wantErr: strconv.ErrRange},
+		{name: "float64", value: "5.10", ptr: new(float64), want: 5.10},
+		{name: "invalid", value: "abc", ptr: new(int), wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decodeValue(tt.value, reflect.ValueOf(tt.ptr).Elem(), CodecOptions{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("decodeValue() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeValue() error = %v", err)
+			}
+			if got := reflect.ValueOf(tt.ptr).Elem().Interface(); got != tt.want {
+				t.Errorf("decodeValue() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterCodec_partial(t *testing.T) {
+	RegisterCodec(timeType, nil, func(s string, v reflect.Value, opts CodecOptions) error {
+		v.Set(reflect.ValueOf(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)))
+		return nil
+	})
+	defer func() {
+		codecsMu.Lock()
+		delete(codecs, timeType)
+		codecsMu.Unlock()
+	}()
+	type event struct {
+		At time.Time `redisearch:",time=unix"`
+	}
+	values, err := encodeHash(event{At: time.Unix(1682937000, 0)})
+	if err != nil {
+		t.Fatalf("encodeHash() error = %v", err)
+	}
+	if want := []interface{}{"At", "1682937000"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("encodeHash() = %v, want %v, the built-in encoder must be kept", values, want)
+	}
+	var out []event
+	if _, err := decodeMaps([]map[string]string{{"At": "1"}}, nil, reflect.ValueOf(&out), DecodeOptions{}); err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	if out[0].At.Year() != 2023 {
+		t.Errorf("decodeMaps() = %v, want the registered decoder to be used", out[0].At)
+	}
+}
+
+func Test_decodeTime_utc(t *testing.T) {
+	for _, format := range []string{TimeFormatUnix, TimeFormatUnixMilli} {
+		var got time.Time
+		if err := decodeValue("1682937000", reflect.ValueOf(&got).Elem(), CodecOptions{TimeFormat: format}); err != nil {
+			t.Fatalf("decodeValue() error = %v", err)
+		}
+		if got.Location() != time.UTC {
+			t.Errorf("decodeValue(%s) location = %v, want UTC", format, got.Location())
+		}
+	}
+}
+
+func Test_decodeValue_vectorBlob(t *testing.T) {
+	var f32 []float32
+	if err := decodeValue(string([]byte{0x00, 0x00, 0x80, 0x3f}), reflect.ValueOf(&f32).Elem(), CodecOptions{}); err != nil || !reflect.DeepEqual(f32, []float32{1}) {
+		t.Errorf("decodeValue() = %v, %v, want [1]", f32, err)
+	}
+	if err := decodeValue("abcde", reflect.ValueOf(&f32).Elem(), CodecOptions{}); err == nil {
+		t.Error("decodeValue() expected error for a FLOAT32 blob of 5 bytes")
+	}
+	var f64 []float64
+	if err := decodeValue("abcd", reflect.ValueOf(&f64).Elem(), CodecOptions{}); err == nil {
+		t.Error("decodeValue() expected error for a FLOAT64 blob of 4 bytes")
+	}
+}
@@ -0,0 +1,209 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// cursorCloseTimeout bound of the FT.CURSOR DEL sent by Close
+const cursorCloseTimeout = 5 * time.Second
+
+// CursorOptions options of the cursor created by AggregateWithCursor (WITHCURSOR COUNT {count} MAXIDLE {idle})
+type CursorOptions struct {
+	// Count number of rows to read per batch. If not set the server default is used (1000)
+	Count int
+	// MaxIdle the maximum idle time of the cursor, it is automatically deleted by the server after this period of inactivity.
+	// It is sent in milliseconds, rounded up (ie: 1µs is sent as 1ms). If not set the server default is used (300s)
+	MaxIdle time.Duration
+}
+
+// Cursor iterate over the batches of rows of an aggregation created with WITHCURSOR.
+// Close must always be called to release the server side cursor
+//
+//	cursor, err := search.AggregateWithCursor(ctx, opts, CursorOptions{Count: 500})
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close()
+//	for cursor.Next(ctx) {
+//		var rows []map[string]string
+//		if err := cursor.Scan(&rows); err != nil {
+//			return err
+//		}
+//	}
+//	return cursor.Err()
+type Cursor struct {
+	r         *RediSearch
+	indexName string
+	opts      CursorOptions
+	decode    DecodeOptions
+	id        int64
+	total     int64
+	// pending is set when the current batch has been read from redis but not yet returned by Next
+	pending bool
+	rows    []map[string]string
+	// warnings of the last Scan, in non strict decode mode
+	warnings []*DecodeError
+	err      error
+}
+
+// AggregateWithCursor run an aggregation pipeline using a cursor, so the results can be read in batches using the returned Cursor
+func (r *RediSearch) AggregateWithCursor(ctx stdContext.Context, opts AggregateOptions, cursorOpts CursorOptions) (*Cursor, error) {
+	if opts.IndexName == "" {
+		return nil, errors.New("missing required IndexName")
+	}
+	args := append(aggregateArgs(opts), cursorOpts.args()...)
+	do := r.client.Do(ctx, args...)
+	res, err := do.Result()
+	if err != nil {
+		return nil, err
+	}
+	c := &Cursor{
+		r:         r,
+		indexName: opts.IndexName,
+		opts:      cursorOpts,
+		decode:    r.decode,
+	}
+	if err := c.read(res); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Next prepare the next batch of rows to be read with Scan. It returns false when the cursor is exhausted or an error occurs,
+// Err should be checked after Next returns false
+func (c *Cursor) Next(ctx stdContext.Context) bool {
+	if c.err != nil {
+		return false
+	}
+	for {
+		if c.pending {
+			c.pending = false
+			if len(c.rows) != 0 {
+				return true
+			}
+		}
+		if c.id == 0 {
+			c.rows = nil
+			return false
+		}
+
+		// Cursors are local to the node that created them, so the command is routed by index name like FT.AGGREGATE
+		do := c.r.doKey(ctx, 2, cursorReadArgs(c.indexName, c.id, c.opts)...)
+		res, err := do.Result()
+		if err != nil {
+			c.err = err
+			return false
+		}
+		if err := c.read(res); err != nil {
+			c.err = err
+			return false
+		}
+	}
+}
+
+// Scan decode the current batch of rows into {out}.
+// {out} must be a pointer to a slice of structs or maps, the same rules used by Search apply
+func (c *Cursor) Scan(out interface{}) error {
+	if c.err != nil {
+		return c.err
+	}
+	v, err := outSliceValue(out)
+	if err != nil {
+		return err
+	}
+	c.warnings, err = decodeMaps(c.rows, nil, v, c.decode)
+	return err
+}
+
+// Warnings return the values that could not be decoded by the last Scan, see DecodeOptions
+func (c *Cursor) Warnings() []*DecodeError {
+	return c.warnings
+}
+
+// Total number of results reported by the first batch of the aggregation
+func (c *Cursor) Total() int64 {
+	return c.total
+}
+
+// Err return the error, if any, that was encountered during iteration
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// Close delete the server side cursor if it was not exhausted yet. It is safe to call Close multiple times,
+// and it can be retried if it fails. A background context bounded by a timeout is used so the cursor is released
+// even if the iteration context was cancelled. Cursors already released by the server (ie: MaxIdle expired) are closed without error
+func (c *Cursor) Close() error {
+	c.rows = nil
+	c.pending = false
+	if c.id == 0 {
+		return nil
+	}
+	ctx, cancel := stdContext.WithTimeout(stdContext.Background(), cursorCloseTimeout)
+	defer cancel()
+	do := c.r.doKey(ctx, 2, cursorDelArgs(c.indexName, c.id)...)
+	if _, err := do.Result(); err != nil && !isUnknownCursor(err) {
+		return err
+	}
+	c.id = 0
+	return nil
+}
+
+// isUnknownCursor return true if {err} is the error returned by redisearch when the cursor does not exist (anymore)
+func isUnknownCursor(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "cursor does not exist") || strings.Contains(msg, "cursor not found")
+}
+
+// read a cursor reply ([[total row1 row2 ...] cursorID]) and store it as the pending batch
+func (c *Cursor) read(raw interface{}) error {
+	resSlice, ok := raw.([]interface{})
+	if !ok || len(resSlice) != 2 {
+		return fmt.Errorf("invalid redis cursor response: %v", raw)
+	}
+	id, ok := resSlice[1].(int64)
+	if !ok {
+		return fmt.Errorf("invalid redis cursor id type: %T", resSlice[1])
+	}
+	total, rows, err := parseAggregateResults(resSlice[0])
+	if err != nil {
+		return err
+	}
+	c.id = id
+	c.rows = rows
+	c.pending = true
+	if c.total == 0 {
+		c.total = total
+	}
+	return nil
+}
+
+// args return the WITHCURSOR arguments of FT.AGGREGATE
+func (o CursorOptions) args() []interface{} {
+	args := []interface{}{"WITHCURSOR"}
+	if o.Count > 0 {
+		args = append(args, "COUNT", o.Count)
+	}
+	if o.MaxIdle > 0 {
+		// rounded up, so idle times under 1ms are not sent as 0
+		args = append(args, "MAXIDLE", int64((o.MaxIdle+time.Millisecond-1)/time.Millisecond))
+	}
+	return args
+}
+
+// cursorReadArgs return the FT.CURSOR READ arguments. MAXIDLE is only accepted on creation, the idle timer is reset on every read
+func cursorReadArgs(indexName string, id int64, opts CursorOptions) []interface{} {
+	args := []interface{}{"FT.CURSOR", "READ", indexName, id}
+	if opts.Count > 0 {
+		args = append(args, "COUNT", opts.Count)
+	}
+	return args
+}
+
+func cursorDelArgs(indexName string, id int64) []interface{} {
+	return []interface{}{"FT.CURSOR", "DEL", indexName, id}
+}
@@ -0,0 +1,179 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursor_read(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       interface{}
+		wantID    int64
+		wantTotal int64
+		wantRows  []map[string]string
+		wantErr   bool
+	}{
+		{
+			name: "ok: batch with open cursor",
+			raw: []interface{}{
+				[]interface{}{int64(10), []interface{}{"tags", "cauca"}, []interface{}{"tags", "valle"}},
+				int64(123),
+			},
+			wantID:    123,
+			wantTotal: 10,
+			wantRows:  []map[string]string{{"tags": "cauca"}, {"tags": "valle"}},
+		},
+		{
+			name: "ok: last batch",
+			raw: []interface{}{
+				[]interface{}{int64(10)},
+				int64(0),
+			},
+			wantID:    0,
+			wantTotal: 10,
+			wantRows:  []map[string]string{},
+		},
+		{
+			name:    "invalid response",
+			raw:     []interface{}{int64(10)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid cursor id",
+			raw:     []interface{}{[]interface{}{int64(10)}, "123"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cursor{}
+			err := c.read(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if c.id != tt.wantID || c.Total() != tt.wantTotal || !c.pending {
+				t.Errorf("read() id = %v total = %v pending = %v, want %v %v true", c.id, c.Total(), c.pending, tt.wantID, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(c.rows, tt.wantRows) {
+				t.Errorf("read() rows = %+v, want %+v", c.rows, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestCursor_exhausted(t *testing.T) {
+	c := &Cursor{}
+	if err := c.read([]interface{}{[]interface{}{int64(1), []interface{}{"count", "3"}}, int64(0)}); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Next(context.Background()) {
+		t.Fatal("Next() = false, want true for pending batch")
+	}
+	var out []struct {
+		Count int `json:"count"`
+	}
+	if err := c.Scan(&out); err != nil || len(out) != 1 || out[0].Count != 3 {
+		t.Errorf("Scan() = %+v, %v", out, err)
+	}
+	if len(c.Warnings()) != 0 {
+		t.Errorf("Warnings() = %v, want none", c.Warnings())
+	}
+	if c.Next(context.Background()) {
+		t.Error("Next() = true, want false for exhausted cursor")
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestCursorOptions_args(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     CursorOptions
+		wantArgs []interface{}
+		wantRead []interface{}
+	}{
+		{
+			name:     "defaults",
+			wantArgs: []interface{}{"WITHCURSOR"},
+			wantRead: []interface{}{"FT.CURSOR", "READ", "cities", int64(7)},
+		},
+		{
+			name:     "count and max idle",
+			opts:     CursorOptions{Count: 500, MaxIdle: 2 * time.Second},
+			wantArgs: []interface{}{"WITHCURSOR", "COUNT", 500, "MAXIDLE", int64(2000)},
+			wantRead: []interface{}{"FT.CURSOR", "READ", "cities", int64(7), "COUNT", 500},
+		},
+		{
+			name:     "max idle under 1ms",
+			opts:     CursorOptions{MaxIdle: time.Microsecond},
+			wantArgs: []interface{}{"WITHCURSOR", "MAXIDLE", int64(1)},
+			wantRead: []interface{}{"FT.CURSOR", "READ", "cities", int64(7)},
+		},
+		{
+			name:     "max idle rounded up",
+			opts:     CursorOptions{MaxIdle: 1500 * time.Microsecond},
+			wantArgs: []interface{}{"WITHCURSOR", "MAXIDLE", int64(2)},
+			wantRead: []interface{}{"FT.CURSOR", "READ", "cities", int64(7)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.args(); !reflect.DeepEqual(got, tt.wantArgs) {
+				t.Errorf("args() = %v, want %v", got, tt.wantArgs)
+			}
+			if got := cursorReadArgs("cities", 7, tt.opts); !reflect.DeepEqual(got, tt.wantRead) {
+				t.Errorf("cursorReadArgs() = %v, want %v", got, tt.wantRead)
+			}
+		})
+	}
+	want := []interface{}{"FT.CURSOR", "DEL", "cities", int64(7)}
+	if got := cursorDelArgs("cities", 7); !reflect.DeepEqual(got, want) {
+		t.Errorf("cursorDelArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestCursor_Close(t *testing.T) {
+	fail := true
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		if fail {
+			return nil, errors.New("connection reset")
+		}
+		return "OK", nil
+	})
+	c := &Cursor{r: r, indexName: "cities", id: 7}
+	if err := c.Close(); err == nil {
+		t.Fatal("Close() expected error")
+	}
+	if c.id != 7 {
+		t.Fatalf("Close() failed but the cursor id was cleared, it can not be retried")
+	}
+	fail = false
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() second call error = %v", err)
+	}
+	want := [][]interface{}{cursorDelArgs("cities", 7), cursorDelArgs("cities", 7)}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Close() commands = %v, want %v", got, want)
+	}
+
+	// the cursor was already released by the server
+	r, _ = newTestClient(func(args []interface{}) (interface{}, error) {
+		return nil, errors.New("Cursor does not exist")
+	})
+	c = &Cursor{r: r, indexName: "cities", id: 7}
+	if err := c.Close(); err != nil || c.id != 0 {
+		t.Errorf("Close() error = %v, id = %d, want the missing cursor to be closed", err, c.id)
+	}
+}
@@ -0,0 +1,75 @@
+package redisearch
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Logger receives the decoding warnings, *log.Logger implements it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// DecodeOptions control how documents are decoded into structs. In non strict mode the values that can not be decoded (and invalid JSON documents) are
+// ignored and sent to Logger as warnings, they are also returned by SearchResult.Warnings and Cursor.Warnings. Search, Aggregate,
+// Profile and ProfileAggregate only report them to Logger
+type DecodeOptions struct {
+	// Strict return a *DecodeError on the first document value that can not be decoded into its struct field (invalid values or
+	// unsupported field types). By default the value is ignored, and the problem is logged and reported as a warning
+	Strict bool
+	// Logger receives the warnings in non strict mode. Defaults to a no-op logger, so unlike previous versions (which logged unsupported
+	// field types using the log package) nothing is logged unless a Logger is set, ie: log.Default()
+	Logger Logger
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Printf(format string, v ...interface{}) {}
+
+func (o DecodeOptions) logger() Logger {
+	if o.Logger == nil {
+		return nopLogger{}
+	}
+	return o.Logger
+}
+
+// DecodeError a document value that could not be decoded into its struct field
+type DecodeError struct {
+	// Key the document key, empty for aggregations
+	Key string
+	// Field the hash field name, $ for a JSON document
+	Field string
+	// Value the raw field value, or the whole JSON document
+	Value string
+	// Type the type of the struct field
+	Type reflect.Type
+	// Err the underlying error (ie: *strconv.NumError)
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	value := e.Value
+	if len(value) > 64 {
+		value = value[:64] + "..."
+	}
+	prefix := ""
+	if e.Key != "" {
+		prefix = e.Key + ": "
+	}
+	if errors.Is(e.Err, errUnsupportedType) {
+		return fmt.Sprintf("%sfield %s: %s type is not supported", prefix, e.Field, e.Type)
+	}
+	return fmt.Sprintf("%sfield %s: can not decode %q into %s: %v", prefix, e.Field, value, e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// WithDecodeOptions return a copy of the client, sharing the same connection pool, that decodes documents using the given options
+func (r *RediSearch) WithDecodeOptions(opts DecodeOptions) Client {
+	c := *r
+	c.decode = opts
+	return &c
+}
@@ -0,0 +1,194 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+type testDecodeModel struct {
+	ID     string `redisearch:"id"`
+	Name   string
+	Count  int
+	Active bool
+	Score  float64
+}
+
+func TestSearchResult_Scan_decodeOptions(t *testing.T) {
+	newResult := func(opts DecodeOptions) *SearchResult {
+		return &SearchResult{
+			Total: 2,
+			Docs: []Document{
+				{Key: "doc:1", Fields: map[string]string{"Name": "one", "Count": "1", "Active": "true", "Score": "1.5"}},
+				{Key: "doc:2", Fields: map[string]string{"Name": "two", "Count": "abc", "Active": "", "Score": "1.5"}},
+			},
+			decode: opts,
+		}
+	}
+
+	t.Run("lenient", func(t *testing.T) {
+		logger := &testLogger{}
+		res := newResult(DecodeOptions{Logger: logger})
+		var out []testDecodeModel
+		if err := res.Scan(&out); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		want := []testDecodeModel{
+			{ID: "doc:1", Name: "one", Count: 1, Active: true, Score: 1.5},
+			{ID: "doc:2", Name: "two", Score: 1.5},
+		}
+		if !reflect.DeepEqual(out, want) {
+			t.Errorf("Scan() = %+v, want %+v", out, want)
+		}
+		var fields []string
+		for _, w := range res.Warnings {
+			if w.Key != "doc:2" {
+				t.Errorf("Scan() warning key = %s, want doc:2", w.Key)
+			}
+			fields = append(fields, w.Field)
+		}
+		if !reflect.DeepEqual(fields, []string{"Count", "Active"}) {
+			t.Errorf("Scan() warnings = %v, want [Count Active]", res.Warnings)
+		}
+		if len(logger.lines) != 2 {
+			t.Errorf("Scan() logged %v, want 2 lines", logger.lines)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		res := newResult(DecodeOptions{Strict: true, Logger: &testLogger{}})
+		var out []testDecodeModel
+		err := res.Scan(&out)
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("Scan() error = %v, want *DecodeError", err)
+		}
+		if decodeErr.Key != "doc:2" || decodeErr.Field != "Count" || decodeErr.Value != "abc" || decodeErr.Type != reflect.TypeOf(0) {
+			t.Errorf("Scan() error = %+v", decodeErr)
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("Scan() error = %v, want it to wrap strconv.ErrSyntax", err)
+		}
+	})
+}
+
+func Test_decodeMaps_overflow(t *testing.T) {
+	var out []struct {
+		Small int8
+		Size  uint64
+	}
+	m := map[string]string{"Small": "1", "Size": "-1"}
+	_, err := decodeMaps([]map[string]string{m}, []string{"doc:1"}, reflect.ValueOf(&out), DecodeOptions{Strict: true})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Field != "Size" || decodeErr.Type != reflect.TypeOf(uint64(0)) {
+		t.Fatalf("decodeMaps() error = %v, want *DecodeError for Size", err)
+	}
+
+	m = map[string]string{"Small": "300", "Size": "18446744073709551615"}
+	warnings, err := decodeMaps([]map[string]string{m}, []string{"doc:1"}, reflect.ValueOf(&out), DecodeOptions{Logger: &testLogger{}})
+	if err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Field != "Small" || !errors.Is(warnings[0], strconv.ErrRange) {
+		t.Errorf("decodeMaps() warnings = %v, want Small out of range", warnings)
+	}
+	if out[0].Small != 0 || out[0].Size != 18446744073709551615 {
+		t.Errorf("decodeMaps() = %+v", out[0])
+	}
+}
+
+func TestDecodeError_Error(t *testing.T) {
+	err := &DecodeError{Key: "doc:1", Field: "Count", Value: "abc", Type: reflect.TypeOf(0), Err: strconv.ErrSyntax}
+	want := `doc:1: field Count: can not decode "abc" into int: invalid syntax`
+	if err.Error() != want {
+		t.Errorf("Error() = %s, want %s", err.Error(), want)
+	}
+	err = &DecodeError{Key: "doc:1", Field: "Values", Value: "1,2", Type: reflect.TypeOf([]int{}), Err: errUnsupportedType}
+	want = `doc:1: field Values: []int type is not supported`
+	if err.Error() != want {
+		t.Errorf("Error() = %s, want %s", err.Error(), want)
+	}
+	err = &DecodeError{Field: "count", Value: "abc", Type: reflect.TypeOf(0), Err: strconv.ErrSyntax}
+	want = `field count: can not decode "abc" into int: invalid syntax`
+	if err.Error() != want {
+		t.Errorf("Error() = %s, want %s", err.Error(), want)
+	}
+}
+
+func Test_decodeMaps_jsonIgnoredID(t *testing.T) {
+	type doc struct {
+		ID   string `json:"-" redisearch:"id"`
+		Name string `json:"name"`
+	}
+	var out []doc
+	hash := map[string]string{"name": "one"}
+	jsonDoc := map[string]string{jsonRootPath: `{"name":"two"}`}
+	_, err := decodeMaps([]map[string]string{hash, jsonDoc}, []string{"doc:1", "doc:2"}, reflect.ValueOf(&out), DecodeOptions{Strict: true})
+	if err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	want := []doc{{ID: "doc:1", Name: "one"}, {ID: "doc:2", Name: "two"}}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("decodeMaps() = %+v, want %+v", out, want)
+	}
+}
+
+func Test_decodeMaps_json(t *testing.T) {
+	var out []struct {
+		Name string `json:"name"`
+	}
+	m := map[string]string{jsonRootPath: `{"name":`}
+	_, err := decodeMaps([]map[string]string{m}, []string{"doc:1"}, reflect.ValueOf(&out), DecodeOptions{Strict: true})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Key != "doc:1" || decodeErr.Field != jsonRootPath {
+		t.Errorf("decodeMaps() error = %v, want *DecodeError for doc:1", err)
+	}
+
+	logger := &testLogger{}
+	warnings, err := decodeMaps([]map[string]string{m}, []string{"doc:1"}, reflect.ValueOf(&out), DecodeOptions{Logger: logger})
+	if err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Field != jsonRootPath || len(logger.lines) != 1 {
+		t.Errorf("decodeMaps() warnings = %v, logged %v, want the invalid document", warnings, logger.lines)
+	}
+}
+
+func TestRediSearch_Search_decodeWarnings(t *testing.T) {
+	r, _ := newTestClient(func(args []interface{}) (interface{}, error) {
+		return []interface{}{int64(1), "doc:1", []interface{}{"Count", "abc"}}, nil
+	})
+	var out []testDecodeModel
+	// the default logger discards the warnings
+	if _, err := r.Search(context.Background(), SearchOptions{IndexName: "docs", Query: "*"}, &out); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	logger := &testLogger{}
+	c := r.WithDecodeOptions(DecodeOptions{Logger: logger})
+	if _, err := c.Search(context.Background(), SearchOptions{IndexName: "docs", Query: "*"}, &out); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(logger.lines) != 1 {
+		t.Errorf("Search() logged %v, want 1 line", logger.lines)
+	}
+}
+
+func TestRediSearch_WithDecodeOptions(t *testing.T) {
+	r := &RediSearch{}
+	c := r.WithDecodeOptions(DecodeOptions{Strict: true}).(*RediSearch)
+	if !c.decode.Strict || r.decode.Strict {
+		t.Errorf("WithDecodeOptions() = %+v, original %+v", c.decode, r.decode)
+	}
+}
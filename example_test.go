@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/redis/go-redis/v9"
+	"time"
 )
 
 func ExampleRediSearch_CreateIndex() {
@@ -154,3 +155,112 @@ func ExampleRediSearch_DropIndex() {
 	}
 	println("index deleted")
 }
+
+func ExampleRediSearch_Aggregate() {
+	search := New(&redis.Options{
+		Network:    "tcp",
+		Addr:       "redisAddress",
+		Password:   "redisPassword",
+		DB:         0,
+		MaxRetries: 5,
+	})
+
+	ctx := context.Background()
+	var out []struct {
+		Tags          string  `json:"tags"`
+		Count         int     `json:"count"`
+		AvgPopulation float64 `json:"avg_population"`
+	}
+	total, err := search.Aggregate(ctx, AggregateOptions{
+		IndexName: "cities",
+		Steps: []AggregateStep{
+			AggregateGroupBy([]string{"tags"},
+				ReducerCount("count"),
+				ReducerAvg("population", "avg_population"),
+			),
+			AggregateSortBy(10, SortBy{FieldName: "count", Descending: true}),
+			AggregateLimit(0, 10),
+		},
+	}, &out)
+	if err != nil {
+		println("got error: ", err.Error())
+		return
+	}
+	fmt.Printf("aggregate results: %d %+v", total, out)
+}
+
+func ExampleRediSearch_AggregateWithCursor() {
+	search := New(&redis.Options{
+		Network:    "tcp",
+		Addr:       "redisAddress",
+		Password:   "redisPassword",
+		DB:         0,
+		MaxRetries: 5,
+	})
+
+	ctx := context.Background()
+	cursor, err := search.AggregateWithCursor(ctx, AggregateOptions{
+		IndexName: "cities",
+		Steps: []AggregateStep{
+			AggregateGroupBy([]string{"tags"}, ReducerCount("count")),
+		},
+	}, CursorOptions{Count: 500, MaxIdle: time.Minute})
+	if err != nil {
+		println("got error: ", err.Error())
+		return
+	}
+	defer cursor.Close() // always release the server side cursor
+	for cursor.Next(ctx) {
+		var rows []map[string]string
+		if err := cursor.Scan(&rows); err != nil {
+			println("got error: ", err.Error())
+			return
+		}
+		fmt.Printf("batch: %+v", rows)
+	}
+	if err := cursor.Err(); err != nil {
+		println("got error: ", err.Error())
+	}
+}
+
+func ExampleRediSearch_AliasUpdate() {
+	search := New(&redis.Options{
+		Network:    "tcp",
+		Addr:       "redisAddress",
+		Password:   "redisPassword",
+		DB:         0,
+		MaxRetries: 5,
+	})
+
+	ctx := context.Background()
+	// point the "cities" alias to the new index, searches using the alias are not interrupted
+	err := search.AliasUpdate(ctx, "cities", "cities_v2")
+	if err != nil {
+		println("got error: ", err.Error())
+		return
+	}
+	println("alias updated")
+}
+
+func ExampleRediSearch_WaitForIndex() {
+	search := New(&redis.Options{
+		Network:    "tcp",
+		Addr:       "redisAddress",
+		Password:   "redisPassword",
+		DB:         0,
+		MaxRetries: 5,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	err := search.WaitForIndex(ctx, "cities", WaitOptions{
+		Progress: func(info *IndexInfo) {
+			fmt.Printf("indexed: %.0f%%\n", info.PercentIndexed*100)
+		},
+	})
+	if err != nil {
+		println("got error: ", err.Error())
+		return
+	}
+	println("index ready")
+}
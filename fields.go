@@ -0,0 +1,182 @@
+package redisearch
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	nestedSeparatorMu sync.RWMutex
+	nestedSeparator   = "."
+
+	// fieldCache the fields of each struct type (map[fieldCacheKey][]structField), see structFields
+	fieldCache sync.Map
+)
+
+// fieldCacheKey the fields of a type depend on the nested separator and the registered codecs, so both are part of the key.
+// Fields built while the configuration changes are stored under the old configuration, and never returned afterwards
+type fieldCacheKey struct {
+	t         reflect.Type
+	separator string
+	codecs    uint64
+}
+
+// SetNestedSeparator set the separator used to build the hash field names of nested struct fields (ie: address.city).
+// Defaults to "." It must be set before storing documents, as it is used both to encode and decode them
+func SetNestedSeparator(separator string) {
+	nestedSeparatorMu.Lock()
+	defer nestedSeparatorMu.Unlock()
+	nestedSeparator = separator
+	clearFieldCache()
+}
+
+func getNestedSeparator() string {
+	nestedSeparatorMu.RLock()
+	defer nestedSeparatorMu.RUnlock()
+	return nestedSeparator
+}
+
+// structField a field stored in a hash, after promoting embedded structs and flattening nested structs
+type structField struct {
+	// name the hash field name
+	name  string
+	index []int
+	field reflect.StructField
+	opts  CodecOptions
+	// id the field is the document id (only top level and promoted fields can be the document id)
+	id bool
+}
+
+// structFields return the fields of a struct type the way they are stored in a hash:
+// * fields of anonymous (embedded) structs are promoted like encoding/json does, unless they have a json name
+// * fields of named nested structs are flattened using the nested separator (ie: address.city)
+// * structs handled by a codec or encoding.TextMarshaler (ie: time.Time) are regular fields
+// If more than one field has the same name the shallower one wins, then the first one declared.
+// Fields are cached per type, the returned slice must not be modified
+func structFields(t reflect.Type) []structField {
+	key := fieldCacheKey{t: t, separator: getNestedSeparator(), codecs: codecsVersion()}
+	if fields, ok := fieldCache.Load(key); ok {
+		return fields.([]structField)
+	}
+	fields, _ := fieldCache.LoadOrStore(key, typeFields(t, key.separator))
+	return fields.([]structField)
+}
+
+// clearFieldCache drop the cached struct fields when the nested separator or the codecs change, they would never be used again
+func clearFieldCache() {
+	fieldCache.Range(func(key, _ interface{}) bool {
+		fieldCache.Delete(key)
+		return true
+	})
+}
+
+// typeFields build the fields of a struct type flattening nested structs with {separator}, see structFields
+func typeFields(t reflect.Type, separator string) []structField {
+	var fields []structField
+	depths := make(map[string]int)
+	var walk func(t reflect.Type, prefix string, index []int, visited map[reflect.Type]bool)
+	walk = func(t reflect.Type, prefix string, index []int, visited map[reflect.Type]bool) {
+		visited[t] = true
+		defer delete(visited, t)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fieldIndex := append(index[:len(index):len(index)], i)
+			name := fieldName(field)
+			if prefix == "" && isIDField(field) {
+				// the document id is never stored, `json:"-"` only keeps it out of JSON documents
+				if name == "-" {
+					name = field.Name
+				}
+			} else if name == "-" {
+				continue
+			}
+
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && !isValueStruct(fieldType) {
+				if visited[fieldType] {
+					continue // recursive type
+				}
+				if field.Anonymous && !hasJSONName(field) {
+					walk(fieldType, prefix, fieldIndex, visited)
+				} else if field.IsExported() {
+					walk(fieldType, prefix+name+separator, fieldIndex, visited)
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+
+			name = prefix + name
+			if depth, ok := depths[name]; ok && depth <= len(fieldIndex) {
+				continue
+			}
+			f := structField{
+				name:  name,
+				index: fieldIndex,
+				field: field,
+				opts:  fieldCodecOptions(field),
+				id:    prefix == "" && isIDField(field),
+			}
+			if _, ok := depths[name]; ok {
+				for j := range fields {
+					if fields[j].name == name {
+						fields[j] = f
+					}
+				}
+			} else {
+				fields = append(fields, f)
+			}
+			depths[name] = len(fieldIndex)
+		}
+	}
+	walk(t, "", nil, make(map[reflect.Type]bool))
+	// declaration order, as encoding/json does
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+// isValueStruct return true if the struct type is encoded as a single value instead of being flattened
+func isValueStruct(t reflect.Type) bool {
+	if c := lookupCodec(t); c.encoder != nil || c.decoder != nil {
+		return true
+	}
+	return t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType)
+}
+
+// hasJSONName return true if the field has a name set using the json tag
+func hasJSONName(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name != ""
+}
+
+// fieldByIndex return the nested field of v, nil pointers are allocated if alloc is true.
+// It returns false if a nil pointer is found and it can not be allocated (alloc is false or it is an unexported embedded struct)
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return reflect.Value{}, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,159 @@
+package redisearch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testMetadata struct {
+	ID        string    `redisearch:"id"`
+	CreatedAt time.Time `json:"created_at" redisearch:",time=unix"`
+	Version   int       `json:"version"`
+}
+
+type testAudit struct {
+	Author string `json:"author"`
+}
+
+type testAddress struct {
+	City    string `json:"city" redisearch:"tag"`
+	Country string `json:"country"`
+}
+
+type testNode struct {
+	Name string
+	Next *testNode
+}
+
+type testCustomer struct {
+	testMetadata
+	*testAudit
+	Name     string       `json:"name" redisearch:"text"`
+	Version  string       `json:"version"` // shadows testMetadata.Version
+	Address  testAddress  `json:"address"`
+	Billing  *testAddress `json:"billing"`
+	Tree     testNode
+	Internal testAddress `json:"-"`
+}
+
+func Test_structFields(t *testing.T) {
+	var names []string
+	for _, field := range structFields(reflect.TypeOf(testCustomer{})) {
+		if field.id {
+			names = append(names, "id:"+field.name)
+			continue
+		}
+		names = append(names, field.name)
+	}
+	want := []string{
+		"id:ID", "created_at", "author", "name", "version",
+		"address.city", "address.country", "billing.city", "billing.country", "Tree.Name",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("structFields() = %v, want %v", names, want)
+	}
+}
+
+func Test_structFields_cache(t *testing.T) {
+	hasField := func(name string) bool {
+		for _, field := range structFields(reflect.TypeOf(testCustomer{})) {
+			if field.name == name {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasField("address.city") {
+		t.Fatalf("structFields() missing address.city")
+	}
+
+	SetNestedSeparator("_")
+	// fields built with the old separator by a concurrent call, stored after the cache was cleared
+	customerType := reflect.TypeOf(testCustomer{})
+	fieldCache.Store(fieldCacheKey{t: customerType, separator: ".", codecs: codecsVersion()}, typeFields(customerType, "."))
+	if !hasField("address_city") {
+		t.Errorf("structFields() missing address_city, fields built with the old separator must not be used")
+	}
+	SetNestedSeparator(".")
+
+	addressType := reflect.TypeOf(testAddress{})
+	RegisterCodec(addressType, func(v reflect.Value, opts CodecOptions) (string, error) {
+		return v.Field(0).String(), nil
+	}, nil)
+	defer func() {
+		codecsMu.Lock()
+		delete(codecs, addressType)
+		codecsMu.Unlock()
+		clearFieldCache()
+	}()
+	if !hasField("address") || hasField("address.city") {
+		t.Errorf("structFields() must not flatten types with a codec, RegisterCodec must clear the cache")
+	}
+}
+
+func Test_nestedStructs(t *testing.T) {
+	created := time.Unix(1682937000, 0)
+	in := testCustomer{
+		testMetadata: testMetadata{ID: "customer:1", CreatedAt: created, Version: 1},
+		Name:         "John",
+		Version:      "v2",
+		Address:      testAddress{City: "Popayan", Country: "Colombia"},
+	}
+	values, err := encodeHash(in)
+	if err != nil {
+		t.Fatalf("encodeHash() error = %v", err)
+	}
+	wantValues := []interface{}{
+		"created_at", "1682937000",
+		"name", "John",
+		"version", "v2",
+		"address.city", "Popayan",
+		"address.country", "Colombia",
+		"Tree.Name", "",
+	}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Fatalf("encodeHash() = %v, want %v", values, wantValues)
+	}
+
+	m := map[string]string{
+		"created_at":      "1682937000",
+		"author":          "Jane",
+		"name":            "John",
+		"version":         "v2",
+		"address.city":    "Popayan",
+		"address.country": "Colombia",
+		"billing.city":    "Cali",
+	}
+	var out []testCustomer
+	if _, err := decodeMaps([]map[string]string{m}, []string{"customer:1"}, reflect.ValueOf(&out), DecodeOptions{}); err != nil {
+		t.Fatalf("decodeMaps() error = %v", err)
+	}
+	got := out[0]
+	if got.ID != "customer:1" || !got.CreatedAt.Equal(created) || got.Name != "John" || got.Version != "v2" || got.Address != in.Address {
+		t.Errorf("decodeMaps() = %+v", got)
+	}
+	if got.testAudit != nil {
+		t.Errorf("decodeMaps() unexported embedded pointers can not be allocated, got %+v", got.testAudit)
+	}
+	if got.Billing == nil || got.Billing.City != "Cali" {
+		t.Errorf("decodeMaps() billing = %+v", got.Billing)
+	}
+}
+
+func TestSetNestedSeparator(t *testing.T) {
+	SetNestedSeparator("_")
+	defer SetNestedSeparator(".")
+	schema, err := SchemaFromStruct(testCustomer{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct() error = %v", err)
+	}
+	want := map[string]FieldSchema{
+		"name":         {Type: FieldTypeText},
+		"address_city": {Type: FieldTypeTag},
+		"billing_city": {Type: FieldTypeTag},
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("SchemaFromStruct() = %v, want %v", schema, want)
+	}
+}
@@ -0,0 +1,347 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IndexInfo index definition and statistics, as reported by FT.INFO.
+// Statistics not reported by the server version in use are left empty
+type IndexInfo struct {
+	// IndexName the physical index name (even if FT.INFO was called using an alias)
+	IndexName    string
+	IndexOptions []string
+	Definition   IndexDefinition
+	Attributes   []IndexAttribute
+	// StopWords the custom stop words of the index, nil if the default list is used
+	StopWords []string
+
+	NumDocs  int64
+	MaxDocID int64
+	NumTerms int64
+	// NumRecords total number of records in the inverted indexes
+	NumRecords int64
+
+	InvertedSizeMB           float64
+	VectorIndexSizeMB        float64
+	TotalInvertedIndexBlocks int64
+	OffsetVectorsSizeMB      float64
+	DocTableSizeMB           float64
+	SortableValuesSizeMB     float64
+	KeyTableSizeMB           float64
+	RecordsPerDocAvg         float64
+	BytesPerRecordAvg        float64
+	OffsetsPerTermAvg        float64
+	OffsetBitsPerRecordAvg   float64
+
+	// HashIndexingFailures number of documents that could not be indexed (ie: invalid numeric values)
+	HashIndexingFailures int64
+	// TotalIndexingTime time spent indexing, in milliseconds
+	TotalIndexingTime float64
+	// Indexing true while the index is being populated by the background scan
+	Indexing bool
+	// PercentIndexed progress of the background scan, between 0 and 1
+	PercentIndexed float64
+	NumberOfUses   int64
+
+	GCStats     IndexGCStats
+	CursorStats IndexCursorStats
+}
+
+type IndexDefinition struct {
+	// KeyType see IndexOn* constants
+	KeyType         string
+	Prefixes        []string
+	Filter          string
+	DefaultLanguage string
+	LanguageField   string
+	DefaultScore    float64
+	ScoreField      string
+	PayloadField    string
+}
+
+type IndexAttribute struct {
+	// Identifier the hash field name or JSONPath of the attribute
+	Identifier string
+	// Attribute the name used to reference the attribute in queries
+	Attribute string
+	// Type see FieldType* constants
+	Type      string
+	Weight    float64
+	Separator string
+	Phonetic  string
+	Sortable  bool
+	NoStem    bool
+	NoIndex   bool
+}
+
+type IndexGCStats struct {
+	BytesCollected       int64
+	TotalMsRun           float64
+	TotalCycles          int64
+	AverageCycleTimeMs   float64
+	LastRunTimeMs        float64
+	GCNumericTreesMissed int64
+	GCBlocksDenied       int64
+}
+
+type IndexCursorStats struct {
+	GlobalIdle    int64
+	GlobalTotal   int64
+	IndexCapacity int64
+	IndexTotal    int64
+}
+
+// IndexInfo return the definition and statistics of the given index (or alias)
+func (r *RediSearch) IndexInfo(ctx stdContext.Context, name string) (*IndexInfo, error) {
+	if name == "" {
+		return nil, errors.New("missing required index name")
+	}
+	do := r.client.Do(ctx, "FT.INFO", name)
+	raw, err := do.Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseIndexInfo(raw)
+}
+
+// parseIndexInfo parse the FT.INFO reply. Both RESP2 (flat arrays) and RESP3 (maps) replies are supported,
+// as well as the different layouts used by RediSearch 2.x versions
+func parseIndexInfo(raw interface{}) (*IndexInfo, error) {
+	info, err := infoMap(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &IndexInfo{
+		IndexName:                infoString(info["index_name"]),
+		NumDocs:                  infoInt(info["num_docs"]),
+		MaxDocID:                 infoInt(info["max_doc_id"]),
+		NumTerms:                 infoInt(info["num_terms"]),
+		NumRecords:               infoInt(info["num_records"]),
+		InvertedSizeMB:           infoFloat(info["inverted_sz_mb"]),
+		VectorIndexSizeMB:        infoFloat(info["vector_index_sz_mb"]),
+		TotalInvertedIndexBlocks: infoInt(info["total_inverted_index_blocks"]),
+		OffsetVectorsSizeMB:      infoFloat(info["offset_vectors_sz_mb"]),
+		DocTableSizeMB:           infoFloat(info["doc_table_size_mb"]),
+		SortableValuesSizeMB:     infoFloat(info["sortable_values_size_mb"]),
+		KeyTableSizeMB:           infoFloat(info["key_table_size_mb"]),
+		RecordsPerDocAvg:         infoFloat(info["records_per_doc_avg"]),
+		BytesPerRecordAvg:        infoFloat(info["bytes_per_record_avg"]),
+		OffsetsPerTermAvg:        infoFloat(info["offsets_per_term_avg"]),
+		OffsetBitsPerRecordAvg:   infoFloat(info["offset_bits_per_record_avg"]),
+		HashIndexingFailures:     infoInt(info["hash_indexing_failures"]),
+		TotalIndexingTime:        infoFloat(info["total_indexing_time"]),
+		Indexing:                 infoInt(info["indexing"]) != 0,
+		PercentIndexed:           infoFloat(info["percent_indexed"]),
+		NumberOfUses:             infoInt(info["number_of_uses"]),
+		Definition:               IndexDefinition{KeyType: IndexOnHash},
+	}
+	if _, ok := info["percent_indexed"]; !ok && !res.Indexing {
+		res.PercentIndexed = 1
+	}
+	for _, option := range infoSlice(info["index_options"]) {
+		res.IndexOptions = append(res.IndexOptions, infoString(option))
+	}
+	if stopWords, ok := info["stopwords_list"]; ok {
+		res.StopWords = []string{}
+		for _, word := range infoSlice(stopWords) {
+			res.StopWords = append(res.StopWords, infoString(word))
+		}
+	}
+
+	if definition, err := infoMap(info["index_definition"]); err == nil {
+		if keyType := infoString(definition["key_type"]); keyType != "" {
+			res.Definition.KeyType = keyType
+		}
+		for _, prefix := range infoSlice(definition["prefixes"]) {
+			res.Definition.Prefixes = append(res.Definition.Prefixes, infoString(prefix))
+		}
+		res.Definition.Filter = infoString(definition["filter"])
+		res.Definition.DefaultLanguage = infoString(definition["default_language"])
+		res.Definition.LanguageField = infoString(definition["language_field"])
+		res.Definition.DefaultScore = infoFloat(definition["default_score"])
+		res.Definition.ScoreField = infoString(definition["score_field"])
+		res.Definition.PayloadField = infoString(definition["payload_field"])
+	}
+
+	// RediSearch >= 2.2 uses "attributes" [[identifier x attribute y type TEXT ...]], older versions "fields" [[x type TEXT ...]]
+	if attributes, ok := info["attributes"]; ok {
+		for _, rawAttribute := range infoSlice(attributes) {
+			if tokens := infoTokens(rawAttribute); len(tokens) != 0 {
+				res.Attributes = append(res.Attributes, parseIndexAttribute(tokens))
+			}
+		}
+	} else {
+		for _, rawField := range infoSlice(info["fields"]) {
+			tokens := infoTokens(rawField)
+			if len(tokens) == 0 {
+				continue
+			}
+			attribute := parseIndexAttribute(tokens[1:])
+			attribute.Identifier = infoString(tokens[0])
+			attribute.Attribute = attribute.Identifier
+			res.Attributes = append(res.Attributes, attribute)
+		}
+	}
+
+	if gc, err := infoMap(info["gc_stats"]); err == nil {
+		res.GCStats = IndexGCStats{
+			BytesCollected:       infoInt(gc["bytes_collected"]),
+			TotalMsRun:           infoFloat(gc["total_ms_run"]),
+			TotalCycles:          infoInt(gc["total_cycles"]),
+			AverageCycleTimeMs:   infoFloat(gc["average_cycle_time_ms"]),
+			LastRunTimeMs:        infoFloat(gc["last_run_time_ms"]),
+			GCNumericTreesMissed: infoInt(gc["gc_numeric_trees_missed"]),
+			GCBlocksDenied:       infoInt(gc["gc_blocks_denied"]),
+		}
+	}
+	if cursors, err := infoMap(info["cursor_stats"]); err == nil {
+		res.CursorStats = IndexCursorStats{
+			GlobalIdle:    infoInt(cursors["global_idle"]),
+			GlobalTotal:   infoInt(cursors["global_total"]),
+			IndexCapacity: infoInt(cursors["index_capacity"]),
+			IndexTotal:    infoInt(cursors["index_total"]),
+		}
+	}
+	return res, nil
+}
+
+// parseIndexAttribute parse the attribute tokens (ie: identifier x attribute x type TEXT WEIGHT 2 SORTABLE), unknown options are ignored.
+// The same format is used by FT.CREATE field options, so it is also used to normalize FieldSchema
+func parseIndexAttribute(tokens []interface{}) IndexAttribute {
+	var attribute IndexAttribute
+	for i := 0; i < len(tokens); i++ {
+		next := func() string {
+			if i+1 >= len(tokens) {
+				return ""
+			}
+			i++
+			return infoString(tokens[i])
+		}
+		switch strings.ToUpper(infoString(tokens[i])) {
+		case "IDENTIFIER":
+			attribute.Identifier = next()
+		case "ATTRIBUTE":
+			attribute.Attribute = next()
+		case "TYPE":
+			attribute.Type = strings.ToUpper(next())
+		case "SORTABLE":
+			attribute.Sortable = true
+		case "NOSTEM":
+			attribute.NoStem = true
+		case "NOINDEX":
+			attribute.NoIndex = true
+		case "WEIGHT":
+			attribute.Weight, _ = strconv.ParseFloat(next(), 64)
+		case "SEPARATOR":
+			attribute.Separator = next()
+		case "PHONETIC":
+			attribute.Phonetic = next()
+		}
+	}
+	return attribute
+}
+
+// infoMap convert a key/value reply (flat RESP2 array or RESP3 map) into a map
+func infoMap(raw interface{}) (map[string]interface{}, error) {
+	switch reply := raw.(type) {
+	case []interface{}:
+		m := make(map[string]interface{}, len(reply)/2)
+		for i := 0; i+1 < len(reply); i += 2 {
+			m[infoString(reply[i])] = reply[i+1]
+		}
+		return m, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(reply))
+		for k, v := range reply {
+			m[infoString(k)] = v
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("invalid redis response type: %T", raw)
+}
+
+// infoSlice return the reply value as a slice, nil if it is not an array
+func infoSlice(raw interface{}) []interface{} {
+	s, _ := raw.([]interface{})
+	return s
+}
+
+// infoTokens return an attribute reply as a flat list of tokens. RESP3 replies use maps instead of flat arrays
+func infoTokens(raw interface{}) []interface{} {
+	switch reply := raw.(type) {
+	case []interface{}:
+		return reply
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(reply))
+		values := make(map[string]interface{}, len(reply))
+		for k, v := range reply {
+			keys = append(keys, infoString(k))
+			values[infoString(k)] = v
+		}
+		sort.Strings(keys)
+		var tokens []interface{}
+		for _, k := range keys {
+			tokens = append(tokens, k)
+			switch v := values[k].(type) {
+			case []interface{}: // flags (ie: SORTABLE) are returned as a list
+				tokens = append(tokens, v...)
+			default:
+				tokens = append(tokens, v)
+			}
+		}
+		return tokens
+	}
+	return nil
+}
+
+// infoString return the string representation of a reply value
+func infoString(raw interface{}) string {
+	switch v := raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(raw)
+}
+
+// infoInt return the reply value as an integer. Invalid values are reported as 0
+func infoInt(raw interface{}) int64 {
+	switch v := raw.(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	s := infoString(raw)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	return int64(infoFloat(s))
+}
+
+// infoFloat return the reply value as a float. Invalid values (including nan, reported on empty indexes) are reported as 0
+func infoFloat(raw interface{}) float64 {
+	var f float64
+	switch v := raw.(type) {
+	case float64:
+		f = v
+	case int64:
+		f = float64(v)
+	default:
+		f, _ = strconv.ParseFloat(infoString(raw), 64)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+	return f
+}
@@ -0,0 +1,187 @@
+package redisearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ftInfoReply sample FT.INFO reply (RESP2) of an index created with
+// FT.CREATE cities_v1 ON HASH PREFIX 1 city: NOFREQS SCHEMA name TEXT WEIGHT 2 SORTABLE tags TAG population NUMERIC
+var ftInfoReply = []interface{}{
+	"index_name", "cities_v1",
+	"index_options", []interface{}{"NOFREQS"},
+	"index_definition", []interface{}{
+		"key_type", "HASH",
+		"prefixes", []interface{}{"city:"},
+		"default_score", "1",
+	},
+	"attributes", []interface{}{
+		[]interface{}{"identifier", "name", "attribute", "name", "type", "TEXT", "WEIGHT", "2", "SORTABLE"},
+		[]interface{}{"identifier", "tags", "attribute", "tags", "type", "TAG", "SEPARATOR", ","},
+		[]interface{}{"identifier", "population", "attribute", "population", "type", "NUMERIC"},
+	},
+	"num_docs", "3",
+	"max_doc_id", "3",
+	"num_terms", "5",
+	"num_records", "12",
+	"inverted_sz_mb", "0.0002",
+	"vector_index_sz_mb", "0",
+	"total_inverted_index_blocks", "8",
+	"offset_vectors_sz_mb", "1.9e-05",
+	"doc_table_size_mb", "2.1e-4",
+	"sortable_values_size_mb", "0",
+	"key_table_size_mb", "8.6e-05",
+	"records_per_doc_avg", "4",
+	"bytes_per_record_avg", "17.5",
+	"offsets_per_term_avg", "1.5",
+	"offset_bits_per_record_avg", "8",
+	"hash_indexing_failures", "1",
+	"total_indexing_time", "0.254",
+	"indexing", "1",
+	"percent_indexed", "0.5",
+	"number_of_uses", int64(7),
+	"gc_stats", []interface{}{
+		"bytes_collected", "10",
+		"total_ms_run", "2",
+		"total_cycles", "3",
+		"average_cycle_time_ms", "-nan",
+		"last_run_time_ms", "1",
+		"gc_numeric_trees_missed", "0",
+		"gc_blocks_denied", "0",
+	},
+	"cursor_stats", []interface{}{
+		"global_idle", int64(1),
+		"global_total", int64(2),
+		"index_capacity", int64(128),
+		"index_total", int64(2),
+	},
+}
+
+func Test_parseIndexInfo(t *testing.T) {
+	want := &IndexInfo{
+		IndexName:    "cities_v1",
+		IndexOptions: []string{"NOFREQS"},
+		Definition: IndexDefinition{
+			KeyType:      "HASH",
+			Prefixes:     []string{"city:"},
+			DefaultScore: 1,
+		},
+		Attributes: []IndexAttribute{
+			{Identifier: "name", Attribute: "name", Type: "TEXT", Weight: 2, Sortable: true},
+			{Identifier: "tags", Attribute: "tags", Type: "TAG", Separator: ","},
+			{Identifier: "population", Attribute: "population", Type: "NUMERIC"},
+		},
+		NumDocs:                  3,
+		MaxDocID:                 3,
+		NumTerms:                 5,
+		NumRecords:               12,
+		InvertedSizeMB:           0.0002,
+		TotalInvertedIndexBlocks: 8,
+		OffsetVectorsSizeMB:      1.9e-05,
+		DocTableSizeMB:           2.1e-4,
+		KeyTableSizeMB:           8.6e-05,
+		RecordsPerDocAvg:         4,
+		BytesPerRecordAvg:        17.5,
+		OffsetsPerTermAvg:        1.5,
+		OffsetBitsPerRecordAvg:   8,
+		HashIndexingFailures:     1,
+		TotalIndexingTime:        0.254,
+		Indexing:                 true,
+		PercentIndexed:           0.5,
+		NumberOfUses:             7,
+		GCStats: IndexGCStats{
+			BytesCollected: 10,
+			TotalMsRun:     2,
+			TotalCycles:    3,
+			LastRunTimeMs:  1,
+		},
+		CursorStats: IndexCursorStats{
+			GlobalIdle:    1,
+			GlobalTotal:   2,
+			IndexCapacity: 128,
+			IndexTotal:    2,
+		},
+	}
+	got, err := parseIndexInfo(ftInfoReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIndexInfo() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_parseIndexInfo_shapes(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  interface{}
+		want *IndexInfo
+	}{
+		{
+			name: "ok: legacy fields, no indexing stats",
+			raw: []interface{}{
+				"index_name", "cities",
+				"fields", []interface{}{
+					[]interface{}{"name", "type", "TEXT", "WEIGHT", "2", "SORTABLE"},
+				},
+				"indexing", int64(0),
+			},
+			want: &IndexInfo{
+				IndexName:      "cities",
+				Definition:     IndexDefinition{KeyType: "HASH"},
+				Attributes:     []IndexAttribute{{Identifier: "name", Attribute: "name", Type: "TEXT", Weight: 2, Sortable: true}},
+				PercentIndexed: 1,
+			},
+		},
+		{
+			name: "ok: resp3 maps",
+			raw: map[interface{}]interface{}{
+				"index_name": "users",
+				"index_definition": map[interface{}]interface{}{
+					"key_type": "JSON",
+					"prefixes": []interface{}{"user:"},
+				},
+				"attributes": []interface{}{
+					map[interface{}]interface{}{
+						"identifier": "$.name",
+						"attribute":  "name",
+						"type":       "TEXT",
+						"WEIGHT":     float64(1),
+						"flags":      []interface{}{"SORTABLE"},
+					},
+				},
+				"num_docs":        int64(2),
+				"percent_indexed": float64(1),
+			},
+			want: &IndexInfo{
+				IndexName:      "users",
+				Definition:     IndexDefinition{KeyType: "JSON", Prefixes: []string{"user:"}},
+				Attributes:     []IndexAttribute{{Identifier: "$.name", Attribute: "name", Type: "TEXT", Weight: 1, Sortable: true}},
+				NumDocs:        2,
+				PercentIndexed: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIndexInfo(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseIndexInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseIndexInfo_stopWords(t *testing.T) {
+	info, err := parseIndexInfo([]interface{}{"index_name", "idx"})
+	if err != nil || info.StopWords != nil {
+		t.Errorf("parseIndexInfo() stop words = %v, %v, want nil for the default list", info.StopWords, err)
+	}
+	info, err = parseIndexInfo([]interface{}{"index_name", "idx", "stopwords_list", []interface{}{"el", "la"}})
+	if err != nil || !reflect.DeepEqual(info.StopWords, []string{"el", "la"}) {
+		t.Errorf("parseIndexInfo() stop words = %v, %v, want [el la]", info.StopWords, err)
+	}
+}
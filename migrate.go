@@ -0,0 +1,351 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type MigrationAction string
+
+const (
+	// MigrationNone the index already matches the desired spec
+	MigrationNone MigrationAction = "none"
+	// MigrationCreated the index did not exist, the first version was created along with its alias
+	MigrationCreated MigrationAction = "created"
+	// MigrationAltered new fields were added to the current index using FT.ALTER
+	MigrationAltered MigrationAction = "altered"
+	// MigrationReindexed incompatible changes were found, a new version of the index was created and the alias swapped to it
+	MigrationReindexed MigrationAction = "reindexed"
+)
+
+type MigrateOptions struct {
+	// DropOld drop the previous version of the index once the alias points to the new one. Documents are never deleted
+	DropOld bool
+	// Wait if set, wait for the new version of the index to be fully indexed before swapping the alias. See WaitForIndex
+	Wait *WaitOptions
+}
+
+type MigrationResult struct {
+	Action MigrationAction
+	// Index the physical (versioned) index the alias points to after the migration
+	Index string
+	// Added fields added to the index using FT.ALTER
+	Added []string
+	// Changes incompatible differences that required a new version of the index
+	Changes []string
+}
+
+// AlterIndex add new fields to an existing index using a single FT.ALTER SCHEMA ADD, so either all the fields are added or none.
+// Existing documents are scanned again in background to index the new fields, unless the index was created with SKIPINITIALSCAN
+// (then only documents modified after the change are indexed). Existing fields can not be changed, see Migrate
+func (r *RediSearch) AlterIndex(ctx stdContext.Context, name string, fields map[string]FieldSchema) error {
+	if name == "" || len(fields) == 0 {
+		return errors.New("missing required index name or fields")
+	}
+	args := []interface{}{"FT.ALTER", name, "SCHEMA", "ADD"}
+	for _, field := range sortedFieldNames(fields) {
+		args = append(args, schemaFieldArgs(field, fields[field])...)
+	}
+	do := r.client.Do(ctx, args...)
+	_, err := do.Result()
+	return err
+}
+
+// Migrate make the index match the given spec. opts.IndexName is used as an alias pointing to a versioned physical index ({name}_v{n}):
+// * If the alias does not exist, the first version of the index is created and aliased.
+// * If the spec only adds new fields, they are added to the current index using FT.ALTER.
+// * If the spec changes existing fields, removes fields or changes the index definition (type, prefixes, filter, language, language field,
+// score, score field, payload field, stop words or the NOOFFSETS, NOFIELDS and NOFREQS flags), a new version of the index
+// is created and the alias is swapped to it using FT.ALIASUPDATE, so searches using the alias are never interrupted.
+// Temporary and the flags not reported by FT.INFO (ie: SKIPINITIALSCAN, NOHL, MAXTEXTFIELDS) can not be compared and are ignored.
+// Note that the new index is populated in background, so searches may return partial results until it is fully indexed
+// unless MigrateOptions.Wait is set.
+//
+// If opts.IndexName is the name of an existing index (created without Migrate), it is replaced by an alias on the first incompatible change.
+// In that case the old index has to be dropped before the alias is created, so searches fail in between
+func (r *RediSearch) Migrate(ctx stdContext.Context, opts IndexOptions, migrateOpts MigrateOptions) (*MigrationResult, error) {
+	if opts.IndexName == "" {
+		return nil, errors.New("missing required IndexName")
+	}
+	alias := opts.IndexName
+
+	do := r.client.Do(ctx, "FT.INFO", alias)
+	raw, err := do.Result()
+	if err != nil {
+		if !isUnknownIndex(err) {
+			return nil, err
+		}
+		index := versionedIndexName(alias, 1)
+		if err := r.createVersion(ctx, opts, index); err != nil {
+			return nil, err
+		}
+		if err := r.AliasAdd(ctx, alias, index); err != nil {
+			return nil, err
+		}
+		return &MigrationResult{Action: MigrationCreated, Index: index}, nil
+	}
+
+	current, err := parseIndexInfo(raw)
+	if err != nil {
+		return nil, err
+	}
+	added, changes := diffIndex(current, opts)
+	if len(changes) == 0 {
+		if len(added) == 0 {
+			return &MigrationResult{Action: MigrationNone, Index: current.IndexName}, nil
+		}
+		fields := make(map[string]FieldSchema, len(added))
+		for _, field := range added {
+			fields[field] = opts.Schema[field]
+		}
+		if err := r.AlterIndex(ctx, current.IndexName, fields); err != nil {
+			return nil, err
+		}
+		return &MigrationResult{Action: MigrationAltered, Index: current.IndexName, Added: added}, nil
+	}
+
+	index := versionedIndexName(alias, indexVersion(alias, current.IndexName)+1)
+	if err := r.createVersion(ctx, opts, index); err != nil {
+		return nil, err
+	}
+	if migrateOpts.Wait != nil {
+		if err := r.WaitForIndex(ctx, index, *migrateOpts.Wait); err != nil {
+			return nil, err
+		}
+	}
+	if current.IndexName == alias {
+		// not aliased yet, the old index must be dropped to release the name
+		if err := r.DropIndex(ctx, current.IndexName, false); err != nil {
+			return nil, err
+		}
+		if err := r.AliasAdd(ctx, alias, index); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := r.AliasUpdate(ctx, alias, index); err != nil {
+			return nil, err
+		}
+		if migrateOpts.DropOld {
+			if err := r.DropIndex(ctx, current.IndexName, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &MigrationResult{Action: MigrationReindexed, Index: index, Changes: changes}, nil
+}
+
+// createVersion create the physical index {index} using the given spec. Leftovers of a failed migration are dropped (documents are kept)
+func (r *RediSearch) createVersion(ctx stdContext.Context, opts IndexOptions, index string) error {
+	opts.IndexName = index
+	return r.CreateIndex(ctx, opts, true)
+}
+
+// versionedIndexName return the physical index name of the given alias version
+func versionedIndexName(alias string, version int) string {
+	return alias + "_v" + strconv.Itoa(version)
+}
+
+// indexVersion return the version of a physical index created by Migrate, 0 if it is not a versioned index
+func indexVersion(alias, index string) int {
+	version, err := strconv.Atoi(strings.TrimPrefix(index, alias+"_v"))
+	if err != nil || !strings.HasPrefix(index, alias+"_v") {
+		return 0
+	}
+	return version
+}
+
+// diffIndex compare the current index with the desired spec. It returns the fields that can be added using FT.ALTER
+// and the incompatible changes that require a new index
+func diffIndex(current *IndexInfo, desired IndexOptions) (added []string, changes []string) {
+	on := desired.On
+	if on == "" {
+		on = IndexOnHash
+	}
+	if !strings.EqualFold(current.Definition.KeyType, on) {
+		changes = append(changes, fmt.Sprintf("index type: %s -> %s", current.Definition.KeyType, on))
+	}
+	currentPrefixes, prefixes := normalizePrefixes(current.Definition.Prefixes), normalizePrefixes(desired.Prefix)
+	if strings.Join(currentPrefixes, ",") != strings.Join(prefixes, ",") {
+		changes = append(changes, fmt.Sprintf("prefixes: %v -> %v", currentPrefixes, prefixes))
+	}
+	if current.Definition.Filter != desired.Filter {
+		changes = append(changes, fmt.Sprintf("filter: %q -> %q", current.Definition.Filter, desired.Filter))
+	}
+	changes = append(changes, diffIndexOptions(current, desired)...)
+
+	currentFields := make(map[string]IndexAttribute, len(current.Attributes))
+	for _, attribute := range current.Attributes {
+		currentFields[attribute.Identifier] = attribute.normalized()
+	}
+	for _, field := range sortedFieldNames(desired.Schema) {
+		want := schemaAttribute(field, desired.Schema[field]).normalized()
+		got, ok := currentFields[field]
+		if !ok {
+			added = append(added, field)
+			continue
+		}
+		if got != want {
+			changes = append(changes, fmt.Sprintf("field %s: %+v -> %+v", field, got, want))
+		}
+	}
+	var removed []string
+	for field := range currentFields {
+		if _, ok := desired.Schema[field]; !ok {
+			removed = append(removed, field)
+		}
+	}
+	sort.Strings(removed)
+	for _, field := range removed {
+		changes = append(changes, fmt.Sprintf("field %s: removed", field))
+	}
+	return added, changes
+}
+
+// reportedIndexFlags index flags reported by FT.INFO (index_options). MAXTEXTFIELDS is also reported,
+// but it is set by the server when the index grows beyond 32 text fields so it can not be compared
+var reportedIndexFlags = map[string]bool{
+	IndexFlagNoOffsets: true,
+	IndexFlagNoFields:  true,
+	IndexFlagNoFreqs:   true,
+}
+
+// diffIndexOptions compare the index options other than the schema, type, prefixes and filter.
+// Omitted options are compared using the server defaults
+func diffIndexOptions(current *IndexInfo, desired IndexOptions) (changes []string) {
+	definition := current.Definition
+	strOptions := []struct {
+		name, current, desired, def string
+	}{
+		{name: "language", current: definition.DefaultLanguage, desired: desired.Language, def: "english"},
+		{name: "language field", current: definition.LanguageField, desired: desired.LanguageField, def: "__language"},
+		{name: "score field", current: definition.ScoreField, desired: desired.ScoreField, def: "__score"},
+		{name: "payload field", current: definition.PayloadField, desired: desired.PayloadField, def: "__payload"},
+	}
+	for _, option := range strOptions {
+		got, want := defaultString(option.current, option.def), defaultString(option.desired, option.def)
+		if !strings.EqualFold(got, want) {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", option.name, got, want))
+		}
+	}
+
+	score, wantScore := definition.DefaultScore, float64(desired.Score)
+	if score == 0 {
+		score = 1
+	}
+	if wantScore <= 0 {
+		wantScore = 1
+	}
+	if float32(score) != float32(wantScore) {
+		changes = append(changes, fmt.Sprintf("score: %v -> %v", score, wantScore))
+	}
+
+	var flags, wantFlags []string
+	for _, flag := range current.IndexOptions {
+		if reportedIndexFlags[strings.ToUpper(flag)] {
+			flags = append(flags, strings.ToUpper(flag))
+		}
+	}
+	for _, flag := range desired.Flags {
+		if reportedIndexFlags[strings.ToUpper(flag)] {
+			wantFlags = append(wantFlags, strings.ToUpper(flag))
+		}
+	}
+	sort.Strings(flags)
+	sort.Strings(wantFlags)
+	if strings.Join(flags, ",") != strings.Join(wantFlags, ",") {
+		changes = append(changes, fmt.Sprintf("flags: %v -> %v", flags, wantFlags))
+	}
+
+	// nil stop words means the default list, both in FT.INFO and IndexOptions
+	stopWords, wantStopWords := sortedStrings(current.StopWords), sortedStrings(desired.StopWords)
+	if (current.StopWords == nil) != (len(desired.StopWords) == 0) || strings.Join(stopWords, ",") != strings.Join(wantStopWords, ",") {
+		changes = append(changes, fmt.Sprintf("stop words: %v -> %v", current.StopWords, desired.StopWords))
+	}
+	return changes
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+func sortedStrings(s []string) []string {
+	sorted := append([]string(nil), s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// schemaAttribute convert a FieldSchema into an IndexAttribute so it can be compared with the FT.INFO attributes
+func schemaAttribute(field string, schema FieldSchema) IndexAttribute {
+	var tokens []interface{}
+	for _, option := range schema.Options {
+		tokens = append(tokens, option...)
+	}
+	attribute := parseIndexAttribute(tokens)
+	attribute.Identifier = field
+	attribute.Attribute = field
+	if schema.As != "" {
+		attribute.Attribute = schema.As
+	}
+	attribute.Type = strings.ToUpper(schema.Type)
+	return attribute
+}
+
+// normalized set the server default values and clear the options that do not apply to the attribute type,
+// so omitted options match the FT.INFO output
+func (a IndexAttribute) normalized() IndexAttribute {
+	switch a.Type {
+	case FieldTypeText:
+		if a.Weight == 0 {
+			a.Weight = 1
+		}
+	case FieldTypeTag:
+		if a.Separator == "" {
+			a.Separator = ","
+		}
+	case FieldTypeVector:
+		// vector attributes are reported using a different layout, only the type is compared
+		return IndexAttribute{Identifier: a.Identifier, Attribute: a.Attribute, Type: a.Type}
+	}
+	if a.Type != FieldTypeText {
+		a.Weight = 0
+		a.NoStem = false
+		a.Phonetic = ""
+	}
+	if a.Type != FieldTypeTag {
+		a.Separator = ""
+	}
+	return a
+}
+
+// normalizePrefixes remove empty prefixes (all keys) so they can be compared
+func normalizePrefixes(prefixes []string) []string {
+	var normalized []string
+	for _, prefix := range prefixes {
+		if prefix != "" {
+			normalized = append(normalized, prefix)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func sortedFieldNames(schema map[string]FieldSchema) []string {
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isUnknownIndex return true if {err} is the error returned by redisearch when the index does not exist
+func isUnknownIndex(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "unknown index")
+}
@@ -0,0 +1,251 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_diffIndex(t *testing.T) {
+	current, err := parseIndexInfo(ftInfoReply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := func(extra map[string]FieldSchema) map[string]FieldSchema {
+		s := map[string]FieldSchema{
+			"name":       {Type: FieldTypeText, Options: []SchemaOpt{SchemaOptWeight(2), SchemaOptSortable()}},
+			"tags":       {Type: FieldTypeTag},
+			"population": {Type: FieldTypeNumeric},
+		}
+		for k, v := range extra {
+			if v.Type == "" {
+				delete(s, k)
+				continue
+			}
+			s[k] = v
+		}
+		return s
+	}
+	flags := []string{IndexFlagNoFreqs, IndexFlagSkipInitialScan}
+	tests := []struct {
+		name        string
+		desired     IndexOptions
+		wantAdded   []string
+		wantChanges int
+	}{
+		{
+			name:    "ok: no changes",
+			desired: IndexOptions{Prefix: []string{"city:"}, Flags: flags, Schema: schema(nil)},
+		},
+		{
+			name: "ok: additive",
+			desired: IndexOptions{Prefix: []string{"city:"}, Flags: flags, Schema: schema(map[string]FieldSchema{
+				"location": {Type: FieldTypeGeo},
+				"code":     {Type: FieldTypeTag},
+			})},
+			wantAdded: []string{"code", "location"},
+		},
+		{
+			name: "incompatible: field option changed",
+			desired: IndexOptions{Prefix: []string{"city:"}, Flags: flags, Schema: schema(map[string]FieldSchema{
+				"name": {Type: FieldTypeText},
+			})},
+			wantChanges: 1,
+		},
+		{
+			name: "incompatible: field removed and type changed",
+			desired: IndexOptions{Prefix: []string{"city:"}, Flags: flags, Schema: schema(map[string]FieldSchema{
+				"tags":       {},
+				"population": {Type: FieldTypeText},
+			})},
+			wantChanges: 2,
+		},
+		{
+			name:        "incompatible: definition changed",
+			desired:     IndexOptions{On: IndexOnJSON, Prefix: []string{"town:"}, Flags: flags, Schema: schema(nil)},
+			wantChanges: 2,
+		},
+		{
+			name: "ok: explicit defaults",
+			desired: IndexOptions{
+				Prefix: []string{"city:"}, Flags: flags, Schema: schema(nil),
+				Language: "English", Score: 1, ScoreField: "__score", LanguageField: "__language", PayloadField: "__payload",
+			},
+		},
+		{
+			name: "incompatible: options changed",
+			desired: IndexOptions{
+				Prefix: []string{"city:"}, Schema: schema(nil),
+				Language: "spanish", Score: 0.5, PayloadField: "payload", StopWords: []string{"el", "la"},
+			},
+			wantChanges: 5, // language, score, payload field, flags, stop words
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, changes := diffIndex(current, tt.desired)
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("diffIndex() added = %v, want %v", added, tt.wantAdded)
+			}
+			if len(changes) != tt.wantChanges {
+				t.Errorf("diffIndex() changes = %v, want %d changes", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func Test_indexVersion(t *testing.T) {
+	tests := []struct {
+		index string
+		want  int
+	}{
+		{index: "cities_v3", want: 3},
+		{index: "cities", want: 0},
+		{index: "cities_vx", want: 0},
+		{index: "towns_v2", want: 0},
+	}
+	for _, tt := range tests {
+		if got := indexVersion("cities", tt.index); got != tt.want {
+			t.Errorf("indexVersion(%q) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+}
+
+// newMigrateTestClient return a client where each of {indexes} (index or alias name -> index name) is reported by FT.INFO
+// as the index described by ftInfoReply
+func newMigrateTestClient(indexes map[string]string) (*RediSearch, *testRecorder) {
+	return newTestClient(func(args []interface{}) (interface{}, error) {
+		if args[0] != "FT.INFO" {
+			return "OK", nil
+		}
+		index, ok := indexes[args[1].(string)]
+		if !ok {
+			return nil, errors.New("Unknown Index name")
+		}
+		reply := append([]interface{}{}, ftInfoReply...)
+		reply[1] = index
+		return reply, nil
+	})
+}
+
+func TestRediSearch_Migrate(t *testing.T) {
+	spec := func(index string, extra map[string]FieldSchema) IndexOptions {
+		opts := IndexOptions{
+			IndexName: index,
+			Prefix:    []string{"city:"},
+			Flags:     []string{IndexFlagNoFreqs},
+			Schema: map[string]FieldSchema{
+				"name":       {Type: FieldTypeText, Options: []SchemaOpt{SchemaOptWeight(2), SchemaOptSortable()}},
+				"tags":       {Type: FieldTypeTag},
+				"population": {Type: FieldTypeNumeric},
+			},
+		}
+		for k, v := range extra {
+			opts.Schema[k] = v
+		}
+		return opts
+	}
+	changed := map[string]FieldSchema{"name": {Type: FieldTypeText}}
+	added := map[string]FieldSchema{"code": {Type: FieldTypeTag}, "location": {Type: FieldTypeGeo}}
+	tests := []struct {
+		name       string
+		indexes    map[string]string
+		opts       IndexOptions
+		dropOld    bool
+		wantAction MigrationAction
+		wantIndex  string
+		want       [][]interface{}
+	}{
+		{
+			name:       "none",
+			indexes:    map[string]string{"cities": "cities_v1"},
+			opts:       spec("cities", nil),
+			wantAction: MigrationNone,
+			wantIndex:  "cities_v1",
+			want:       [][]interface{}{{"FT.INFO", "cities"}},
+		},
+		{
+			name:       "created",
+			opts:       spec("cities", nil),
+			wantAction: MigrationCreated,
+			wantIndex:  "cities_v1",
+			want: [][]interface{}{
+				{"FT.INFO", "cities"},
+				{"FT.INFO", "cities_v1"},
+				spec("cities_v1", nil).Args(),
+				{"FT.ALIASADD", "cities", "cities_v1"},
+			},
+		},
+		{
+			name:       "altered",
+			indexes:    map[string]string{"cities": "cities_v1"},
+			opts:       spec("cities", added),
+			wantAction: MigrationAltered,
+			wantIndex:  "cities_v1",
+			want: [][]interface{}{
+				{"FT.INFO", "cities"},
+				{"FT.ALTER", "cities_v1", "SCHEMA", "ADD", "code", FieldTypeTag, "location", FieldTypeGeo},
+			},
+		},
+		{
+			name:       "reindexed",
+			indexes:    map[string]string{"cities": "cities_v1", "cities_v1": "cities_v1"},
+			opts:       spec("cities", changed),
+			wantAction: MigrationReindexed,
+			wantIndex:  "cities_v2",
+			want: [][]interface{}{
+				{"FT.INFO", "cities"},
+				{"FT.INFO", "cities_v2"},
+				spec("cities_v2", changed).Args(),
+				{"FT.ALIASUPDATE", "cities", "cities_v2"},
+			},
+		},
+		{
+			name:       "reindexed: drop old",
+			indexes:    map[string]string{"cities": "cities_v1", "cities_v1": "cities_v1"},
+			opts:       spec("cities", changed),
+			dropOld:    true,
+			wantAction: MigrationReindexed,
+			wantIndex:  "cities_v2",
+			want: [][]interface{}{
+				{"FT.INFO", "cities"},
+				{"FT.INFO", "cities_v2"},
+				spec("cities_v2", changed).Args(),
+				{"FT.ALIASUPDATE", "cities", "cities_v2"},
+				{"FT.INFO", "cities_v1"},
+				{"FT.DROPINDEX", "cities_v1"},
+			},
+		},
+		{
+			name:       "reindexed: not aliased",
+			indexes:    map[string]string{"cities": "cities"},
+			opts:       spec("cities", changed),
+			wantAction: MigrationReindexed,
+			wantIndex:  "cities_v1",
+			want: [][]interface{}{
+				{"FT.INFO", "cities"},
+				{"FT.INFO", "cities_v1"},
+				spec("cities_v1", changed).Args(),
+				{"FT.INFO", "cities"},
+				{"FT.DROPINDEX", "cities"},
+				{"FT.ALIASADD", "cities", "cities_v1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, rec := newMigrateTestClient(tt.indexes)
+			res, err := r.Migrate(context.Background(), tt.opts, MigrateOptions{DropOld: tt.dropOld})
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if res.Action != tt.wantAction || res.Index != tt.wantIndex {
+				t.Errorf("Migrate() = %+v, want %s %s", res, tt.wantAction, tt.wantIndex)
+			}
+			if got := rec.commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Migrate() commands = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
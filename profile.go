@@ -0,0 +1,267 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+)
+
+// Profile execution details of a query, as reported by FT.PROFILE. Times are in milliseconds
+type Profile struct {
+	TotalTime            float64
+	ParsingTime          float64
+	PipelineCreationTime float64
+	// Warning reported by the server (ie: timeouts), empty if there are none
+	Warning string
+	// Iterators the root of the query iterators tree, nil if the query does not use any iterator
+	Iterators *ProfileIterator
+	// ResultProcessors the result processors of the pipeline, in execution order
+	ResultProcessors []ProfileResultProcessor
+	// Shards the profile of each shard, only set if the reply has the profiles of more than one shard (the profile of the first shard is used as the top level profile)
+	Shards []Profile
+}
+
+// ProfileIterator a query iterator (ie: TEXT, TAG, NUMERIC, INTERSECT, UNION) and its child iterators
+type ProfileIterator struct {
+	Type string
+	// Term the term or query of the iterator, if any
+	Term    string
+	Time    float64
+	Counter int64
+	Size    int64
+	// Attributes other values reported for the iterator (ie: Query type, Range)
+	Attributes map[string]string
+	Children   []ProfileIterator
+}
+
+// ProfileResultProcessor a step of the result processing pipeline (ie: Index, Scorer, Sorter, Loader)
+type ProfileResultProcessor struct {
+	Type    string
+	Time    float64
+	Counter int64
+}
+
+// Explain return the execution plan of the search query. The same arguments used by Search are sent, so the plan matches the actual search
+func (r *RediSearch) Explain(ctx stdContext.Context, opts SearchOptions) (string, error) {
+	if opts.IndexName == "" {
+		return "", errors.New("missing required IndexName")
+	}
+	if err := opts.Vector.validate(opts.Params); err != nil {
+		return "", err
+	}
+	args := opts.Args()
+	args[0] = "FT.EXPLAIN"
+	do := r.client.Do(ctx, args...)
+	return do.Text()
+}
+
+// ExplainCLI same as Explain, but the execution plan is returned as a list of lines (FT.EXPLAINCLI)
+func (r *RediSearch) ExplainCLI(ctx stdContext.Context, opts SearchOptions) ([]string, error) {
+	if opts.IndexName == "" {
+		return nil, errors.New("missing required IndexName")
+	}
+	if err := opts.Vector.validate(opts.Params); err != nil {
+		return nil, err
+	}
+	args := opts.Args()
+	args[0] = "FT.EXPLAINCLI"
+	do := r.client.Do(ctx, args...)
+	return do.StringSlice()
+}
+
+// Profile run the search using FT.PROFILE, decoding the results into {out} (see Search) and returning the execution profile.
+// Values that can not be decoded are only reported to DecodeOptions.Logger
+func (r *RediSearch) Profile(ctx stdContext.Context, opts SearchOptions, out interface{}) (int64, *Profile, error) {
+	if opts.IndexName == "" {
+		return 0, nil, errors.New("missing required IndexName")
+	}
+	if err := opts.Vector.validate(opts.Params); err != nil {
+		return 0, nil, err
+	}
+	do := r.client.Do(ctx, profileArgs("SEARCH", opts.Args())...)
+	res, err := do.Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	results, profile, err := parseProfileReply(res)
+	if err != nil {
+		return 0, nil, err
+	}
+	total, err := parseSearchResults(results, opts.Flags, out, r.decode)
+	if err != nil {
+		return 0, nil, err
+	}
+	return total, profile, nil
+}
+
+// ProfileAggregate run the aggregation using FT.PROFILE, decoding the rows into {out} (see Aggregate) and returning the execution profile.
+// Values that can not be decoded are only reported to DecodeOptions.Logger
+func (r *RediSearch) ProfileAggregate(ctx stdContext.Context, opts AggregateOptions, out interface{}) (int64, *Profile, error) {
+	if opts.IndexName == "" {
+		return 0, nil, errors.New("missing required IndexName")
+	}
+	v, err := outSliceValue(out)
+	if err != nil {
+		return 0, nil, err
+	}
+	do := r.client.Do(ctx, profileArgs("AGGREGATE", aggregateArgs(opts))...)
+	res, err := do.Result()
+	if err != nil {
+		return 0, nil, err
+	}
+	results, profile, err := parseProfileReply(res)
+	if err != nil {
+		return 0, nil, err
+	}
+	total, rows, err := parseAggregateResults(results)
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := decodeMaps(rows, nil, v, r.decode); err != nil {
+		return 0, nil, err
+	}
+	return total, profile, nil
+}
+
+// profileArgs convert FT.SEARCH/FT.AGGREGATE arguments ({command} index query args...) into FT.PROFILE index {command} QUERY query args...
+func profileArgs(command string, args []interface{}) []interface{} {
+	profile := []interface{}{"FT.PROFILE", args[1], command, "QUERY", args[2]}
+	return append(profile, args[3:]...)
+}
+
+// parseProfileReply split the FT.PROFILE reply ([results profile]) and parse the profile
+func parseProfileReply(raw interface{}) (interface{}, *Profile, error) {
+	resSlice, ok := raw.([]interface{})
+	if !ok || len(resSlice) != 2 {
+		return nil, nil, fmt.Errorf("invalid redis response: %v", raw)
+	}
+	profile, err := parseProfile(resSlice[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return resSlice[0], profile, nil
+}
+
+// parseProfile parse the profile part of the FT.PROFILE reply. RediSearch 2.x replies a list of [key value...] entries,
+// newer versions reply [Shards [[key value key value ...] ...] Coordinator ...]
+func parseProfile(raw interface{}) (*Profile, error) {
+	entries, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid redis response profile type: %T", raw)
+	}
+	if len(entries) < 2 || infoString(entries[0]) != "Shards" {
+		profile := parseProfileEntries(entries)
+		return &profile, nil
+	}
+
+	var shards []Profile
+	for _, rawShard := range infoSlice(entries[1]) {
+		shard := infoSlice(rawShard)
+		// convert the flat key/value list into [key value...] entries
+		shardEntries := make([]interface{}, 0, len(shard)/2)
+		for i := 0; i+1 < len(shard); i += 2 {
+			entry := []interface{}{shard[i]}
+			if infoString(shard[i]) == "Result processors profile" {
+				entry = append(entry, infoSlice(shard[i+1])...)
+			} else {
+				entry = append(entry, shard[i+1])
+			}
+			shardEntries = append(shardEntries, entry)
+		}
+		shards = append(shards, parseProfileEntries(shardEntries))
+	}
+	if len(shards) == 0 {
+		return &Profile{}, nil
+	}
+	profile := shards[0]
+	if len(shards) > 1 {
+		profile.Shards = shards
+	}
+	return &profile, nil
+}
+
+func parseProfileEntries(entries []interface{}) Profile {
+	var profile Profile
+	for _, rawEntry := range entries {
+		entry := infoSlice(rawEntry)
+		if len(entry) < 2 {
+			continue
+		}
+		switch infoString(entry[0]) {
+		case "Total profile time":
+			profile.TotalTime = infoFloat(entry[1])
+		case "Parsing time":
+			profile.ParsingTime = infoFloat(entry[1])
+		case "Pipeline creation time":
+			profile.PipelineCreationTime = infoFloat(entry[1])
+		case "Warning":
+			if warning := infoString(entry[1]); warning != "None" {
+				profile.Warning = warning
+			}
+		case "Iterators profile":
+			if tokens := infoSlice(entry[1]); len(tokens) != 0 {
+				iterator := parseProfileIterator(tokens)
+				profile.Iterators = &iterator
+			}
+		case "Result processors profile":
+			for _, rawProcessor := range entry[1:] {
+				processor, err := infoMap(rawProcessor)
+				if err != nil {
+					continue
+				}
+				profile.ResultProcessors = append(profile.ResultProcessors, ProfileResultProcessor{
+					Type:    infoString(processor["Type"]),
+					Time:    infoFloat(processor["Time"]),
+					Counter: infoInt(processor["Counter"]),
+				})
+			}
+		}
+	}
+	return profile
+}
+
+// parseProfileIterator parse an iterator profile ([Type INTERSECT Time 0.1 Counter 2 Child iterators [child1...] [child2...]]).
+// Children may also be wrapped in a single list, depending on the server version
+func parseProfileIterator(tokens []interface{}) ProfileIterator {
+	var iterator ProfileIterator
+	for i := 0; i+1 < len(tokens); i += 2 {
+		key := infoString(tokens[i])
+		if key == "Child iterators" {
+			for _, rawChild := range tokens[i+1:] {
+				child := infoSlice(rawChild)
+				if len(child) == 0 {
+					continue
+				}
+				if _, wrapped := child[0].([]interface{}); !wrapped {
+					iterator.Children = append(iterator.Children, parseProfileIterator(child))
+					continue
+				}
+				for _, rawWrapped := range child {
+					if wrapped := infoSlice(rawWrapped); len(wrapped) != 0 {
+						iterator.Children = append(iterator.Children, parseProfileIterator(wrapped))
+					}
+				}
+			}
+			break
+		}
+		value := tokens[i+1]
+		switch key {
+		case "Type":
+			iterator.Type = infoString(value)
+		case "Term", "Query":
+			iterator.Term = infoString(value)
+		case "Time":
+			iterator.Time = infoFloat(value)
+		case "Counter":
+			iterator.Counter = infoInt(value)
+		case "Size":
+			iterator.Size = infoInt(value)
+		default:
+			if iterator.Attributes == nil {
+				iterator.Attributes = make(map[string]string)
+			}
+			iterator.Attributes[key] = infoString(value)
+		}
+	}
+	return iterator
+}
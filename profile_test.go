@@ -0,0 +1,175 @@
+package redisearch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_profileArgs(t *testing.T) {
+	got := profileArgs("SEARCH", SearchOptions{
+		IndexName: "idx",
+		Query:     "hello",
+		Limit:     &Limit{Offset: 0, Max: 10},
+	}.Args())
+	want := []interface{}{"FT.PROFILE", "idx", "SEARCH", "QUERY", "hello", "LIMIT", 0, 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("profileArgs() = %v, want %v", got, want)
+	}
+}
+
+func Test_parseProfile(t *testing.T) {
+	want := &Profile{
+		TotalTime:            0.5,
+		ParsingTime:          0.1,
+		PipelineCreationTime: 0.02,
+		Iterators: &ProfileIterator{
+			Type:       "UNION",
+			Time:       0.03,
+			Counter:    2,
+			Attributes: map[string]string{"Query type": "UNION"},
+			Children: []ProfileIterator{
+				{Type: "TEXT", Term: "hello", Time: 0.01, Counter: 1, Size: 1},
+				{Type: "TEXT", Term: "world", Time: 0.01, Counter: 1, Size: 1},
+			},
+		},
+		ResultProcessors: []ProfileResultProcessor{
+			{Type: "Index", Time: 0.04, Counter: 2},
+			{Type: "Scorer", Time: 0.01, Counter: 2},
+		},
+	}
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    *Profile
+		wantErr bool
+	}{
+		{
+			name: "ok: redisearch 2.x",
+			raw: []interface{}{
+				[]interface{}{"Total profile time", "0.5"},
+				[]interface{}{"Parsing time", "0.1"},
+				[]interface{}{"Pipeline creation time", "0.02"},
+				[]interface{}{"Iterators profile", []interface{}{
+					"Type", "UNION", "Query type", "UNION", "Time", "0.03", "Counter", int64(2), "Child iterators",
+					[]interface{}{"Type", "TEXT", "Term", "hello", "Time", "0.01", "Counter", int64(1), "Size", int64(1)},
+					[]interface{}{"Type", "TEXT", "Term", "world", "Time", "0.01", "Counter", int64(1), "Size", int64(1)},
+				}},
+				[]interface{}{"Result processors profile",
+					[]interface{}{"Type", "Index", "Time", "0.04", "Counter", int64(2)},
+					[]interface{}{"Type", "Scorer", "Time", "0.01", "Counter", int64(2)},
+				},
+			},
+			want: want,
+		},
+		{
+			name: "ok: shards",
+			raw: []interface{}{
+				"Shards", []interface{}{[]interface{}{
+					"Total profile time", "0.5",
+					"Parsing time", "0.1",
+					"Pipeline creation time", "0.02",
+					"Warning", "None",
+					"Iterators profile", []interface{}{
+						"Type", "UNION", "Query type", "UNION", "Time", "0.03", "Counter", int64(2), "Child iterators", []interface{}{
+							[]interface{}{"Type", "TEXT", "Term", "hello", "Time", "0.01", "Counter", int64(1), "Size", int64(1)},
+							[]interface{}{"Type", "TEXT", "Term", "world", "Time", "0.01", "Counter", int64(1), "Size", int64(1)},
+						},
+					},
+					"Result processors profile", []interface{}{
+						[]interface{}{"Type", "Index", "Time", "0.04", "Counter", int64(2)},
+						[]interface{}{"Type", "Scorer", "Time", "0.01", "Counter", int64(2)},
+					},
+				}},
+				"Coordinator", []interface{}{},
+			},
+			want: want,
+		},
+		{
+			name:    "invalid type",
+			raw:     "profile",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProfile(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseProfile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseProfileReply(t *testing.T) {
+	raw := []interface{}{
+		[]interface{}{int64(1), "doc:1", []interface{}{"name", "hello"}},
+		[]interface{}{[]interface{}{"Total profile time", "0.5"}},
+	}
+	results, profile, err := parseProfileReply(raw)
+	if err != nil {
+		t.Fatalf("parseProfileReply() error = %v", err)
+	}
+	var out []map[string]string
+	total, err := parseSearchResults(results, nil, &out, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("parseSearchResults() error = %v", err)
+	}
+	if total != 1 || !reflect.DeepEqual(out, []map[string]string{{"name": "hello"}}) {
+		t.Errorf("parseProfileReply() results = %d %v", total, out)
+	}
+	if profile.TotalTime != 0.5 {
+		t.Errorf("parseProfileReply() profile = %+v", profile)
+	}
+}
+
+func TestRediSearch_Explain(t *testing.T) {
+	ctx := context.Background()
+	opts := SearchOptions{IndexName: "cities", Query: "@name:$name", Params: map[string]interface{}{"name": "cali"}}
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		if args[0] == "FT.EXPLAINCLI" {
+			return []interface{}{"@name:UNION {", "  cali", "}"}, nil
+		}
+		return "@name:UNION {\n  cali\n}\n", nil
+	})
+	plan, err := r.Explain(ctx, opts)
+	if err != nil || plan != "@name:UNION {\n  cali\n}\n" {
+		t.Errorf("Explain() = %q, %v", plan, err)
+	}
+	lines, err := r.ExplainCLI(ctx, opts)
+	if err != nil || !reflect.DeepEqual(lines, []string{"@name:UNION {", "  cali", "}"}) {
+		t.Errorf("ExplainCLI() = %q, %v", lines, err)
+	}
+	want := [][]interface{}{
+		{"FT.EXPLAIN", "cities", "@name:$name", "PARAMS", 2, "name", "cali", "DIALECT", 2},
+		{"FT.EXPLAINCLI", "cities", "@name:$name", "PARAMS", 2, "name", "cali", "DIALECT", 2},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Explain() commands = %v, want %v", got, want)
+	}
+}
+
+func TestRediSearch_Profile(t *testing.T) {
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		return []interface{}{
+			[]interface{}{int64(1), "city:1", []interface{}{"name", "cali"}},
+			[]interface{}{[]interface{}{"Total profile time", "0.5"}},
+		}, nil
+	})
+	var out []map[string]string
+	total, profile, err := r.Profile(context.Background(), SearchOptions{IndexName: "cities", Query: "cali", Limit: &Limit{Max: 5}}, &out)
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if total != 1 || !reflect.DeepEqual(out, []map[string]string{{"name": "cali"}}) || profile.TotalTime != 0.5 {
+		t.Errorf("Profile() = %d %v %+v", total, out, profile)
+	}
+	want := [][]interface{}{{"FT.PROFILE", "cities", "SEARCH", "QUERY", "cali", "LIMIT", 0, 5}}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Profile() commands = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,377 @@
+// Package query provides a builder for the RediSearch query language.
+// Nodes render a correctly escaped query string that can be used as redisearch.SearchOptions.Query
+//
+//	q := query.Intersect(
+//		query.Field("title", query.Prefix("popa")),
+//		query.Tag("tags", "colombia", "south-america"),
+//		query.NumericRange("population", query.Inclusive(100000), query.Inf()),
+//	)
+//	search.Search(ctx, redisearch.SearchOptions{IndexName: "cities", Query: q.String()}, &out)
+//
+// Nodes without anything to match (ie: Term(""), Tag("tags") or an empty Union) are empty: groups skip them,
+// a field, negation or optional node wrapping an empty node is empty as well, and an empty node used as the whole query matches all documents (*)
+package query
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Node a node of the query tree
+type Node interface {
+	// String render the node as a query string
+	String() string
+	// atomic return true if the node does not need to be wrapped in parentheses when used as an operand
+	atomic() bool
+}
+
+// specialChars characters that must be escaped in terms and tag values
+const specialChars = ",.<>{}[]\"':;!@#$%^&*()-+=~|/\\ "
+
+// Escape backslash escape the characters that have a special meaning in the query language
+func Escape(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if strings.ContainsRune(specialChars, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+type raw struct {
+	query string
+}
+
+func (n raw) String() string { return n.query }
+func (n raw) atomic() bool   { return false }
+
+// Raw use {q} as it is, without escaping. Useful for syntax not covered by this package
+func Raw(q string) Node {
+	return raw{query: q}
+}
+
+// All match all documents (*)
+func All() Node {
+	return simple{query: "*"}
+}
+
+// Param reference a query parameter ($name) set using SearchOptions.Params
+func Param(name string) Node {
+	return simple{query: "$" + name}
+}
+
+type simple struct {
+	query string
+}
+
+func (n simple) String() string { return n.query }
+func (n simple) atomic() bool   { return true }
+
+// empty a node without anything to match, see isEmpty
+type empty struct{}
+
+func (n empty) String() string { return "*" }
+func (n empty) atomic() bool   { return true }
+
+// isEmpty return true if the node has nothing to match, so it can be skipped by groups and fields
+func isEmpty(node Node) bool {
+	switch n := node.(type) {
+	case nil, empty:
+		return true
+	case group:
+		return len(n.operands()) == 0
+	case field:
+		return isEmpty(n.node)
+	case unary:
+		return isEmpty(n.node)
+	case attributes:
+		return len(n.list()) == 0 && isEmpty(n.node)
+	}
+	return false
+}
+
+// Term a single (escaped) term. An empty term is an empty node
+func Term(term string) Node {
+	if term == "" {
+		return empty{}
+	}
+	return simple{query: Escape(term)}
+}
+
+// Phrase an exact phrase, the words must appear in the given order. A phrase without words is an empty node
+func Phrase(words ...string) Node {
+	if len(words) == 0 {
+		return empty{}
+	}
+	escaped := make([]string, len(words))
+	for i, word := range words {
+		escaped[i] = Escape(word)
+	}
+	return simple{query: `"` + strings.Join(escaped, " ") + `"`}
+}
+
+// Prefix match terms starting with {prefix} (prefix*). An empty prefix is an empty node
+func Prefix(prefix string) Node {
+	if prefix == "" {
+		return empty{}
+	}
+	return simple{query: Escape(prefix) + "*"}
+}
+
+// Fuzzy match terms within the given Levenshtein {distance} of {term}. Distance is clamped between 1 and 3.
+// An empty term is an empty node
+func Fuzzy(term string, distance int) Node {
+	if term == "" {
+		return empty{}
+	}
+	if distance < 1 {
+		distance = 1
+	}
+	if distance > 3 {
+		distance = 3
+	}
+	pad := strings.Repeat("%", distance)
+	return simple{query: pad + Escape(term) + pad}
+}
+
+// Wildcard match terms using a wildcard {pattern} where ? matches a single character and * any number of characters.
+// An empty pattern is an empty node
+func Wildcard(pattern string) Node {
+	if pattern == "" {
+		return empty{}
+	}
+	pattern = strings.ReplaceAll(pattern, `\`, `\\`)
+	pattern = strings.ReplaceAll(pattern, `'`, `\'`)
+	return simple{query: "w'" + pattern + "'"}
+}
+
+type field struct {
+	name string
+	node Node
+}
+
+func (n field) String() string {
+	if isEmpty(n.node) {
+		return empty{}.String()
+	}
+	if n.node.atomic() {
+		return "@" + Escape(n.name) + ":" + n.node.String()
+	}
+	return "@" + Escape(n.name) + ":(" + n.node.String() + ")"
+}
+func (n field) atomic() bool { return true }
+
+// Field limit {node} to the given text field (@field:node). The field name is escaped, if {node} is empty so is the field
+func Field(name string, node Node) Node {
+	return field{name: name, node: node}
+}
+
+// Tag match any of the given tag {values} of a TAG field (@field:{a | b}). The field name and values are escaped,
+// empty values are skipped and a tag without values is an empty node
+func Tag(fieldName string, values ...string) Node {
+	escaped := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			escaped = append(escaped, Escape(value))
+		}
+	}
+	if len(escaped) == 0 {
+		return empty{}
+	}
+	return simple{query: "@" + Escape(fieldName) + ":{" + strings.Join(escaped, " | ") + "}"}
+}
+
+// Bound a numeric range bound. See Inclusive, Exclusive, Inf and NegInf
+type Bound struct {
+	value     float64
+	exclusive bool
+}
+
+func (b Bound) String() string {
+	if math.IsInf(b.value, 1) {
+		return "+inf"
+	}
+	if math.IsInf(b.value, -1) {
+		return "-inf"
+	}
+	s := strconv.FormatFloat(b.value, 'f', -1, 64)
+	if b.exclusive {
+		return "(" + s
+	}
+	return s
+}
+
+// Inclusive a range bound including {value}
+func Inclusive(value float64) Bound {
+	return Bound{value: value}
+}
+
+// Exclusive a range bound excluding {value}
+func Exclusive(value float64) Bound {
+	return Bound{value: value, exclusive: true}
+}
+
+// Inf positive infinity upper bound
+func Inf() Bound {
+	return Bound{value: math.Inf(1)}
+}
+
+// NegInf negative infinity lower bound
+func NegInf() Bound {
+	return Bound{value: math.Inf(-1)}
+}
+
+// NumericRange match values of a NUMERIC field between {min} and {max} (@field:[min max])
+func NumericRange(fieldName string, min, max Bound) Node {
+	return simple{query: fmt.Sprintf("@%s:[%s %s]", Escape(fieldName), min, max)}
+}
+
+// Geo match values of a GEO field within {radius} {unit} (m|km|mi|ft) of the given coordinates (@field:[lon lat radius unit])
+func Geo(fieldName string, longitude, latitude, radius float64, unit string) Node {
+	if unit == "" {
+		unit = "m"
+	}
+	return simple{query: fmt.Sprintf("@%s:[%s %s %s %s]",
+		Escape(fieldName),
+		strconv.FormatFloat(longitude, 'f', -1, 64),
+		strconv.FormatFloat(latitude, 'f', -1, 64),
+		strconv.FormatFloat(radius, 'f', -1, 64),
+		unit,
+	)}
+}
+
+type unary struct {
+	operator string
+	node     Node
+}
+
+func (n unary) String() string {
+	if isEmpty(n.node) {
+		return empty{}.String()
+	}
+	if n.node.atomic() {
+		return n.operator + n.node.String()
+	}
+	return n.operator + "(" + n.node.String() + ")"
+}
+func (n unary) atomic() bool { return true }
+
+// Not exclude the documents matching {node} (-node), if {node} is empty so is the negation
+func Not(node Node) Node {
+	return unary{operator: "-", node: node}
+}
+
+// Optional documents matching {node} are ranked higher, but {node} is not required to match (~node).
+// If {node} is empty so is the optional node
+func Optional(node Node) Node {
+	return unary{operator: "~", node: node}
+}
+
+type group struct {
+	separator string
+	// empty query used when there are no nodes
+	empty string
+	nodes []Node
+}
+
+func (n group) String() string {
+	nodes := n.operands()
+	switch len(nodes) {
+	case 0:
+		return n.empty
+	case 1:
+		return nodes[0].String()
+	}
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.String()
+	}
+	return "(" + strings.Join(parts, n.separator) + ")"
+}
+
+// atomic a group with a single operand is rendered as the operand itself, so it is as atomic as the operand
+func (n group) atomic() bool {
+	nodes := n.operands()
+	return len(nodes) != 1 || nodes[0].atomic()
+}
+
+// operands return the non empty nodes of the group
+func (n group) operands() []Node {
+	nodes := make([]Node, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		if !isEmpty(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Union match documents matching any of the given nodes ((a | b)). nil and empty nodes are ignored,
+// if there are no nodes all documents are matched (*), like Intersect
+func Union(nodes ...Node) Node {
+	return group{separator: " | ", empty: "*", nodes: nodes}
+}
+
+// Intersect match documents matching all the given nodes ((a b)). nil and empty nodes are ignored,
+// if there are no nodes all documents are matched (*)
+func Intersect(nodes ...Node) Node {
+	return group{separator: " ", empty: "*", nodes: nodes}
+}
+
+// Attributes query attributes applied to a node. Zero values are not rendered
+type Attributes struct {
+	// Weight the weight of the node in the score of the document
+	Weight float64
+	// Slop the maximum number of intervening terms allowed between phrase terms
+	Slop *int
+	// InOrder require the phrase terms to appear in the same order as in the query
+	InOrder *bool
+	// Phonetic enable or disable phonetic matching
+	Phonetic *bool
+}
+
+type attributes struct {
+	node  Node
+	attrs Attributes
+}
+
+func (n attributes) String() string {
+	attrs := n.list()
+	if len(attrs) == 0 {
+		return n.node.String()
+	}
+	return "(" + n.node.String() + ")=>{" + strings.Join(attrs, " ") + "}"
+}
+
+// atomic without attributes the node is rendered as it is, so it is as atomic as the node
+func (n attributes) atomic() bool {
+	return len(n.list()) != 0 || n.node.atomic()
+}
+
+// list return the rendered attributes that are set
+func (n attributes) list() []string {
+	var attrs []string
+	if n.attrs.Weight != 0 {
+		attrs = append(attrs, "$weight: "+strconv.FormatFloat(n.attrs.Weight, 'f', -1, 64)+";")
+	}
+	if n.attrs.Slop != nil {
+		attrs = append(attrs, "$slop: "+strconv.Itoa(*n.attrs.Slop)+";")
+	}
+	if n.attrs.InOrder != nil {
+		attrs = append(attrs, "$inorder: "+strconv.FormatBool(*n.attrs.InOrder)+";")
+	}
+	if n.attrs.Phonetic != nil {
+		attrs = append(attrs, "$phonetic: "+strconv.FormatBool(*n.attrs.Phonetic)+";")
+	}
+	return attrs
+}
+
+// WithAttributes apply the given attributes to {node} ((node)=>{$weight: 2;})
+func WithAttributes(node Node, attrs Attributes) Node {
+	return attributes{node: node, attrs: attrs}
+}
@@ -0,0 +1,82 @@
+package query
+
+import "testing"
+
+func TestNode_String(t *testing.T) {
+	slop := 2
+	inOrder := true
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{name: "term", node: Term("hello"), want: "hello"},
+		{name: "escaped term", node: Term("e-mail user@host"), want: `e\-mail\ user\@host`},
+		{name: "phrase", node: Phrase("hello", "world"), want: `"hello world"`},
+		{name: "prefix", node: Prefix("hel"), want: "hel*"},
+		{name: "fuzzy", node: Fuzzy("helo", 2), want: "%%helo%%"},
+		{name: "fuzzy clamped", node: Fuzzy("helo", 5), want: "%%%helo%%%"},
+		{name: "wildcard", node: Wildcard("he?lo*'s"), want: `w'he?lo*\'s'`},
+		{name: "field", node: Field("title", Term("hello")), want: "@title:hello"},
+		{name: "field with raw", node: Field("title", Raw("hello world")), want: "@title:(hello world)"},
+		{name: "field with union", node: Field("title", Union(Term("a"), Term("b"))), want: "@title:(a | b)"},
+		{name: "tag", node: Tag("tags", "south-america", "new york"), want: `@tags:{south\-america | new\ york}`},
+		{name: "numeric range", node: NumericRange("price", Exclusive(10), Inclusive(20.5)), want: "@price:[(10 20.5]"},
+		{name: "numeric infinite", node: NumericRange("price", NegInf(), Inf()), want: "@price:[-inf +inf]"},
+		{name: "geo", node: Geo("location", -76.6, 2.44, 10, "km"), want: "@location:[-76.6 2.44 10 km]"},
+		{name: "geo default unit", node: Geo("location", 1, 2, 3, ""), want: "@location:[1 2 3 m]"},
+		{name: "not", node: Not(Term("a")), want: "-a"},
+		{name: "not raw", node: Not(Raw("a b")), want: "-(a b)"},
+		{name: "optional", node: Optional(Term("a")), want: "~a"},
+		{name: "intersect", node: Intersect(Term("a"), nil, Not(Term("b"))), want: "(a -b)"},
+		{name: "intersect single", node: Intersect(Term("a")), want: "a"},
+		{name: "intersect empty", node: Intersect(), want: "*"},
+		{name: "union empty", node: Union(nil), want: "*"},
+		{name: "field with empty union", node: Field("f", Union()), want: "*"},
+		{name: "empty term", node: Term(""), want: "*"},
+		{name: "empty phrase", node: Phrase(), want: "*"},
+		{name: "empty prefix", node: Prefix(""), want: "*"},
+		{name: "empty fuzzy", node: Fuzzy("", 2), want: "*"},
+		{name: "empty wildcard", node: Wildcard(""), want: "*"},
+		{name: "intersect skips empty prefix, fuzzy and wildcard", node: Intersect(Prefix(""), Term("a"), Fuzzy("", 1), Wildcard("")), want: "a"},
+		{name: "tag without values", node: Tag("t"), want: "*"},
+		{name: "tag with empty values", node: Tag("t", "", "a"), want: "@t:{a}"},
+		{name: "intersect skips empty nodes", node: Intersect(Term(""), Tag("t"), Field("f", Union()), Term("a")), want: "a"},
+		{name: "union of empty nodes", node: Union(Term(""), Intersect(Tag("t"))), want: "*"},
+		{name: "not empty union", node: Not(Union()), want: "*"},
+		{name: "optional tag without values", node: Optional(Tag("t")), want: "*"},
+		{name: "intersect skips empty negation", node: Intersect(Term("a"), Not(Tag("t"))), want: "a"},
+		{name: "field with single raw group", node: Field("f", Intersect(Raw("a b"))), want: "@f:(a b)"},
+		{name: "not with single raw group", node: Not(Union(nil, Raw("a b"))), want: "-(a b)"},
+		{name: "field with single term group", node: Field("f", Intersect(Term("a"))), want: "@f:a"},
+		{name: "escaped field name", node: Field("address.city", Term("cali")), want: `@address\.city:cali`},
+		{name: "escaped tag field name", node: Tag("my-tags", "a"), want: `@my\-tags:{a}`},
+		{name: "escaped numeric field name", node: NumericRange("unit price", Inclusive(1), Inf()), want: `@unit\ price:[1 +inf]`},
+		{name: "param", node: Field("title", Param("q")), want: "@title:$q"},
+		{name: "all", node: All(), want: "*"},
+		{
+			name: "attributes",
+			node: WithAttributes(Phrase("a", "b"), Attributes{Weight: 2.5, Slop: &slop, InOrder: &inOrder}),
+			want: `("a b")=>{$weight: 2.5; $slop: 2; $inorder: true;}`,
+		},
+		{name: "empty attributes", node: WithAttributes(Term("a"), Attributes{}), want: "a"},
+		{name: "not with empty attributes", node: Not(WithAttributes(Raw("a b"), Attributes{})), want: "-(a b)"},
+		{name: "not with attributes", node: Not(WithAttributes(Raw("a b"), Attributes{Weight: 2})), want: "-(a b)=>{$weight: 2;}"},
+		{
+			name: "nested",
+			node: Intersect(
+				Field("title", Prefix("popa")),
+				Union(Tag("tags", "a"), Tag("tags", "b")),
+				Not(NumericRange("population", Inclusive(0), Exclusive(1000))),
+			),
+			want: "(@title:popa* (@tags:{a} | @tags:{b}) -@population:[0 (1000])",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,26 +2,71 @@ package redisearch
 
 import (
 	stdContext "context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
-	"log"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Client hold basic methods to interact with redisearch module for redis
 type Client interface {
 	Search(ctx stdContext.Context, opts SearchOptions, out interface{}) (int64, error)
+	SearchDocuments(ctx stdContext.Context, opts SearchOptions) (*SearchResult, error)
+	Aggregate(ctx stdContext.Context, opts AggregateOptions, out interface{}) (int64, error)
+	AggregateWithCursor(ctx stdContext.Context, opts AggregateOptions, cursorOpts CursorOptions) (*Cursor, error)
 	CreateIndex(ctx stdContext.Context, opts IndexOptions, dropIfExists bool) error
 	DropIndex(ctx stdContext.Context, name string, purgeIndexData bool) error
 	IndexExists(ctx stdContext.Context, name string) (bool, error)
+	IndexInfo(ctx stdContext.Context, name string) (*IndexInfo, error)
+	WaitForIndex(ctx stdContext.Context, name string, opts WaitOptions) error
 	Add(ctx stdContext.Context, key string, value interface{}, override bool) error
 	Put(ctx stdContext.Context, key string, value interface{}, override bool) error
+	PutIfVersion(ctx stdContext.Context, key string, value interface{}, version int64, override bool) (int64, error)
+	PutJSON(ctx stdContext.Context, key string, value interface{}) error
 	Delete(ctx stdContext.Context, key string) error
+	PutMany(ctx stdContext.Context, docs interface{}, opts BulkOptions) error
+	DeleteMany(ctx stdContext.Context, keys []string, opts BulkOptions) error
+	AlterIndex(ctx stdContext.Context, name string, fields map[string]FieldSchema) error
+	Migrate(ctx stdContext.Context, opts IndexOptions, migrateOpts MigrateOptions) (*MigrationResult, error)
+	AliasAdd(ctx stdContext.Context, alias, index string) error
+	AliasUpdate(ctx stdContext.Context, alias, index string) error
+	AliasDel(ctx stdContext.Context, alias string) error
+	SugAdd(ctx stdContext.Context, key, suggestion string, score float64, opts SugAddOptions) (int64, error)
+	SugGet(ctx stdContext.Context, key, prefix string, opts SugGetOptions) ([]Suggestion, error)
+	SugDel(ctx stdContext.Context, key, suggestion string) (bool, error)
+	SugLen(ctx stdContext.Context, key string) (int64, error)
+	SugLoad(ctx stdContext.Context, key string, opts SugLoadOptions) (int64, error)
+	SpellCheck(ctx stdContext.Context, index, query string, opts SpellCheckOptions) (map[string][]SpellSuggestion, error)
+	SearchWithSpellCheck(ctx stdContext.Context, opts SearchOptions, spellOpts SpellCheckOptions, out interface{}) (int64, *DidYouMean, error)
+	DictAdd(ctx stdContext.Context, dict string, terms ...string) (int64, error)
+	DictDel(ctx stdContext.Context, dict string, terms ...string) (int64, error)
+	DictDump(ctx stdContext.Context, dict string) ([]string, error)
+	SynUpdate(ctx stdContext.Context, index, groupID string, terms []string, opts SynUpdateOptions) error
+	SynDump(ctx stdContext.Context, index string) (map[string][]string, error)
+	SynSync(ctx stdContext.Context, index string, groups map[string][]string, opts SynUpdateOptions) ([]string, error)
+	Explain(ctx stdContext.Context, opts SearchOptions) (string, error)
+	ExplainCLI(ctx stdContext.Context, opts SearchOptions) ([]string, error)
+	Profile(ctx stdContext.Context, opts SearchOptions, out interface{}) (int64, *Profile, error)
+	ProfileAggregate(ctx stdContext.Context, opts AggregateOptions, out interface{}) (int64, *Profile, error)
+	WithDecodeOptions(opts DecodeOptions) Client
 }
 
+const (
+	// tagName struct tag used to set redisearch specific options to struct fields
+	tagName = "redisearch"
+	// tagID mark a string struct field as the document id (`redisearch:"id"`). It is populated with the document key on search
+	// and ignored on Put
+	tagID = "id"
+	// jsonRootPath field name used by RediSearch to return the whole JSON document
+	jsonRootPath = "$"
+)
+
 var supportedDataTypes = map[reflect.Kind]struct{}{
 	reflect.String:  {},
 	reflect.Bool:    {},
@@ -42,7 +87,8 @@ var supportedDataTypes = map[reflect.Kind]struct{}{
 
 // RediSearch implements Client
 type RediSearch struct {
-	client *redis.Client
+	client redis.UniversalClient
+	decode DecodeOptions
 }
 
 // New return a new redisearch implementation instance
@@ -51,6 +97,20 @@ func New(opts *redis.Options) Client {
 	return &RediSearch{client: client}
 }
 
+// NewUniversal return a new redisearch implementation instance using redis.NewUniversalClient:
+// a cluster client if more than one address is set, a failover (sentinel) client if MasterName is set, otherwise a single node client
+func NewUniversal(opts *redis.UniversalOptions) Client {
+	client := redis.NewUniversalClient(opts)
+	return &RediSearch{client: client}
+}
+
+// NewWithClient return a new redisearch implementation instance using an existing client, so its connection pool can be shared.
+// On cluster deployments (*redis.ClusterClient) index commands are routed by index name, which requires the RediSearch coordinator
+// (ie: Redis Stack or Redis Enterprise) so every node can serve any index
+func NewWithClient(client redis.UniversalClient) Client {
+	return &RediSearch{client: client}
+}
+
 // Add legacy name used for Put()
 // DEPRECATED kept for compatibility
 func (r *RediSearch) Add(ctx stdContext.Context, key string, value interface{}, override bool) error {
@@ -60,63 +120,79 @@ func (r *RediSearch) Add(ctx stdContext.Context, key string, value interface{},
 // Put simple wrapper for redis.HSet. This is just a utility, you can still use the data added using HSET command
 // key: set key
 // value: map ([string]string or [string]interface{}) or struct to be stored in the set
-// override: Delete precious set to create a fresh one only with the values provided
+// override: Delete precious set to create a fresh one only with the values provided. DEL and HSET are sent in a single
+// MULTI/EXEC transaction, so the document is never seen missing
 func (r *RediSearch) Put(ctx stdContext.Context, key string, value interface{}, override bool) error {
 	if key == "" || value == nil {
 		return errors.New("invalid key or nil value")
 	}
 
-	if override {
-		if err := r.client.Del(ctx, key).Err(); err != nil {
-			return err
-		}
-	}
-
-	val := reflect.ValueOf(value)
-	if val.Kind() == reflect.Invalid {
-		return errors.New("invalid {value} type")
-	}
-	if val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
-		val = val.Elem()
+	values, err := encodeHash(value)
+	if err != nil {
+		return err
 	}
-	if val.Kind() != reflect.Map && val.Kind() != reflect.Struct {
-		return errors.New("{values} arg must be of type map or struct")
+	if !override {
+		return r.client.HSet(ctx, key, values...).Err()
 	}
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, values...)
+		return nil
+	})
+	return err
+}
 
-	var values []interface{}
-	for i := 0; i < val.NumField(); i++ {
-		if _, ok := supportedDataTypes[val.Field(i).Type().Kind()]; !ok {
-			continue // ignore unsupported type
-		}
-		k := val.Type().Field(i).Name
-		if tag := val.Type().Field(i).Tag.Get("json"); tag != "" {
-			tagSlice := strings.Split(tag, ",")
-			if tagSlice[0] != "" {
-				k = tagSlice[0]
-			}
-		}
-		if k != "" && k != "-" {
-			v := val.Field(i).Interface()
-			values = append(values, k, v)
-		}
+// PutJSON store {value} as a JSON document using JSON.SET (requires the RedisJSON module).
+// value: any value that can be encoded using json.Marshal. The whole document is replaced
+func (r *RediSearch) PutJSON(ctx stdContext.Context, key string, value interface{}) error {
+	if key == "" || value == nil {
+		return errors.New("invalid key or nil value")
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
 	}
-	return r.client.HSet(ctx, key, values...).Err()
+	return r.client.Do(ctx, "JSON.SET", key, "$", string(b)).Err()
 }
 
-// Delete drop document attached to the given key
+// Delete drop document attached to the given key, use DeleteMany to drop multiple keys (even across cluster slots) at once
 func (r *RediSearch) Delete(ctx stdContext.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
-// Search the index with a textual query
+// Search the index with a textual query. {opts.IndexName} can be an index or an alias.
+// Values that can not be decoded are only reported to DecodeOptions.Logger, use SearchDocuments to get them as warnings
 func (r *RediSearch) Search(ctx stdContext.Context, opts SearchOptions, out interface{}) (int64, error) {
 	if opts.IndexName == "" {
 		return 0, errors.New("missing required (IndexName or Query)")
 	}
+	if err := opts.Vector.validate(opts.Params); err != nil {
+		return 0, err
+	}
+	do := r.client.Do(ctx, opts.Args()...)
+	res, err := do.Result()
+	if err != nil {
+		return 0, err
+	}
+	return parseSearchResults(res, opts.Flags, out, r.decode)
+}
+
+// Args build the FT.SEARCH command arguments, as sent by Search
+func (opts SearchOptions) Args() []interface{} {
+	query := opts.Query
+	if opts.Vector != nil {
+		query = opts.Vector.query(query)
+		if len(opts.Return) != 0 {
+			opts.Return = append(opts.Return[:len(opts.Return):len(opts.Return)], opts.Vector.scoreAlias())
+		}
+		if opts.SortBy == nil && opts.Vector.Radius == 0 {
+			opts.SortBy = &SortBy{FieldName: opts.Vector.scoreAlias()}
+		}
+	}
 	args := []interface{}{
 		"FT.SEARCH",
 		opts.IndexName,
-		opts.Query,
+		query,
 	}
 
 	for _, flag := range opts.Flags {
@@ -127,21 +203,22 @@ func (r *RediSearch) Search(ctx stdContext.Context, opts SearchOptions, out inte
 		args = append(args,
 			"FILTER",
 			filter.NumericFieldName,
-			filter.Min,
-			filter.Max,
+			formatFloat32(filter.Min),
+			formatFloat32(filter.Max),
 		)
 	}
 	if opts.GeoFilter != nil {
-		if opts.GeoFilter.Unit == "" {
-			opts.GeoFilter.Unit = "m"
+		unit := opts.GeoFilter.Unit
+		if unit == "" {
+			unit = "m"
 		}
 		args = append(args,
 			"GEOFILTER",
 			opts.GeoFilter.GeoFieldName,
-			opts.GeoFilter.Longitude,
-			opts.GeoFilter.Latitude,
-			opts.GeoFilter.Radius,
-			opts.GeoFilter.Unit,
+			formatFloat32(opts.GeoFilter.Longitude),
+			formatFloat32(opts.GeoFilter.Latitude),
+			formatFloat32(opts.GeoFilter.Radius),
+			unit,
 		)
 	}
 
@@ -230,7 +307,7 @@ func (r *RediSearch) Search(ctx stdContext.Context, opts SearchOptions, out inte
 	if opts.Slop != nil {
 		args = append(args,
 			"SLOP",
-			opts.Slop,
+			*opts.Slop,
 		)
 	}
 
@@ -282,33 +359,130 @@ func (r *RediSearch) Search(ctx stdContext.Context, opts SearchOptions, out inte
 		)
 	}
 
-	do := r.client.Do(ctx, args...)
+	params, dialect := opts.Params, opts.Dialect
+	if opts.Vector != nil {
+		params = make(map[string]interface{}, len(opts.Params)+1)
+		for k, v := range opts.Params {
+			params[k] = v
+		}
+		params[defaultVectorParam] = opts.Vector.Vector
+	}
+	return append(args, paramsArgs(params, dialect)...)
+}
+
+// paramsArgs build the PARAMS and DIALECT arguments. Parameters are sorted by name so the command is deterministic.
+// DIALECT 2 is used if there are parameters and no dialect (or a lower one) was set, as it is required by PARAMS
+func paramsArgs(params map[string]interface{}, dialect int) []interface{} {
+	var args []interface{}
+	if len(params) != 0 {
+		names := make([]string, 0, len(params))
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		args = append(args, "PARAMS", len(params)*2)
+		for _, name := range names {
+			value := params[name]
+			if blob, ok := vectorBlob(reflect.ValueOf(value)); ok {
+				value = blob
+			}
+			args = append(args, name, value)
+		}
+		if dialect < 2 {
+			dialect = 2
+		}
+	}
+	if dialect > 0 {
+		args = append(args, "DIALECT", dialect)
+	}
+	return args
+}
+
+// String render the FT.SEARCH command as a redis-cli command
+func (opts SearchOptions) String() string {
+	return commandString(opts.Args())
+}
+
+// SearchDocuments search the index with a textual query and return the raw documents along with their keys, scores, payloads and sort keys
+// (depending on the search flags used). SearchResult.Scan can be used to decode the documents into a list of structs or maps
+func (r *RediSearch) SearchDocuments(ctx stdContext.Context, opts SearchOptions) (*SearchResult, error) {
+	if opts.IndexName == "" {
+		return nil, errors.New("missing required (IndexName or Query)")
+	}
+	if err := opts.Vector.validate(opts.Params); err != nil {
+		return nil, err
+	}
+	do := r.client.Do(ctx, opts.Args()...)
 	res, err := do.Result()
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	result, err := parseSearchReply(res, opts.Flags)
+	if err != nil {
+		return nil, err
 	}
-	return parseSearchResults(res, out)
+	result.decode = r.decode
+	return result, nil
+}
+
+// Scan decode the documents into the given list of structs or maps.
+// The document key is set into the struct field tagged with `redisearch:"id"` if any.
+// Values that can not be decoded are reported in Warnings, or as a *DecodeError if strict decoding is used (see DecodeOptions)
+func (s *SearchResult) Scan(out interface{}) error {
+	v, err := outSliceValue(out)
+	if err != nil {
+		return err
+	}
+	parsedMaps := make([]map[string]string, len(s.Docs))
+	keys := make([]string, len(s.Docs))
+	for i, doc := range s.Docs {
+		parsedMaps[i] = doc.Fields
+		if parsedMaps[i] == nil {
+			parsedMaps[i] = map[string]string{}
+		}
+		keys[i] = doc.Key
+	}
+	s.Warnings, err = decodeMaps(parsedMaps, keys, v, s.decode)
+	return err
 }
 
 // CreateIndex with the given spec
 func (r *RediSearch) CreateIndex(ctx stdContext.Context, opts IndexOptions, dropIfExists bool) error {
-	exists, err := r.IndexExists(ctx, opts.IndexName)
-	if err != nil {
+	index, err := r.resolveIndexName(ctx, opts.IndexName)
+	exists := err == nil
+	if err != nil && !isUnknownIndex(err) {
 		return err
 	}
 	if exists && dropIfExists {
-		if err := r.DropIndex(ctx, opts.IndexName, false); err != nil {
+		if index != opts.IndexName {
+			return aliasDropError(opts.IndexName, index)
+		}
+		if err := r.dropIndex(ctx, index, false); err != nil {
 			return err
 		}
 	} else if exists {
 		return errors.New("index already exists")
 	}
 
+	do := r.client.Do(ctx, opts.Args()...)
+	if _, err := do.Result(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Args build the FT.CREATE command arguments, as sent by CreateIndex. Schema fields are sorted by name so the command is deterministic
+func (opts IndexOptions) Args() []interface{} {
+	on := opts.On
+	if on == "" {
+		on = IndexOnHash
+	}
 	args := []interface{}{
 		"FT.CREATE",
 		opts.IndexName,
 		"ON",
-		"HASH",
+		on,
 	}
 	if pLen := len(opts.Prefix); pLen != 0 {
 		p := make([]interface{}, pLen+2)
@@ -329,7 +503,7 @@ func (r *RediSearch) CreateIndex(ctx stdContext.Context, opts IndexOptions, drop
 		args = append(args, "LANGUAGE_FIELD", opts.LanguageField)
 	}
 	if opts.Score > 0 {
-		args = append(args, "SCORE", opts.Score)
+		args = append(args, "SCORE", formatFloat32(opts.Score))
 	}
 	if opts.ScoreField != "" {
 		args = append(args, "SCORE_FIELD", opts.ScoreField)
@@ -338,7 +512,7 @@ func (r *RediSearch) CreateIndex(ctx stdContext.Context, opts IndexOptions, drop
 		args = append(args, "PAYLOAD_FIELD", opts.PayloadField)
 	}
 	if opts.Temporary > 0 {
-		args = append(args, "TEMPORARY", opts.Temporary)
+		args = append(args, "TEMPORARY", formatFloat32(opts.Temporary))
 	}
 	if swLen := len(opts.StopWords); swLen != 0 {
 		sw := make([]interface{}, swLen+2)
@@ -358,25 +532,116 @@ func (r *RediSearch) CreateIndex(ctx stdContext.Context, opts IndexOptions, drop
 	}
 	if len(opts.Schema) != 0 {
 		args = append(args, "SCHEMA")
-		for field, schema := range opts.Schema {
-			args = append(args, field, schema.Type)
-			for _, option := range schema.Options {
-				args = append(args, option...)
+		for _, field := range sortedFieldNames(opts.Schema) {
+			args = append(args, schemaFieldArgs(field, opts.Schema[field])...)
+		}
+	}
+	return args
+}
+
+// String render the FT.CREATE command as a redis-cli command
+func (opts IndexOptions) String() string {
+	return commandString(opts.Args())
+}
+
+// formatFloat32 format a float32 option using its shortest representation (2.45, not 2.450000047683716 as go-redis would send it)
+func formatFloat32(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// commandString render command arguments the way go-redis sends them, quoting the arguments that redis-cli would split or unescape
+func commandString(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		var s string
+		switch v := arg.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		case float32:
+			// go-redis sends float32 values as float64, Args pre-formats its float32 fields (see formatFloat32)
+			s = strconv.FormatFloat(float64(v), 'f', -1, 64)
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			s = "0"
+			if v {
+				s = "1"
 			}
+		default:
+			s = fmt.Sprint(arg)
 		}
+		parts[i] = quoteArg(s)
 	}
-	do := r.client.Do(ctx, args...)
-	if _, err := do.Result(); err != nil {
-		return err
+	return strings.Join(parts, " ")
+}
+
+// quoteArg quote the argument if needed, escaping quotes, backslashes and non printable bytes (ie: vector blobs)
+func quoteArg(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return r == '"' || r == '\'' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r)
+	}) < 0 {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == utf8.RuneError || !unicode.IsPrint(r):
+			for _, c := range []byte(s[i : i+size]) {
+				fmt.Fprintf(&b, `\x%02x`, c)
+			}
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
 	}
-	return nil
+	b.WriteByte('"')
+	return b.String()
 }
 
-// DropIndex with the given name. Optionally delete all indexed data
+// schemaFieldArgs build the arguments of a single schema field ({field} [AS {as}] {type} {options...})
+func schemaFieldArgs(field string, schema FieldSchema) []interface{} {
+	args := []interface{}{field}
+	if schema.As != "" {
+		args = append(args, "AS", schema.As)
+	}
+	args = append(args, schema.Type)
+	for _, option := range schema.Options {
+		args = append(args, option...)
+	}
+	return args
+}
+
+// DropIndex with the given name. Optionally delete all indexed data.
+// Aliases are rejected, so the index behind an alias is never dropped by mistake. Use IndexInfo to get the index name
+// of an alias, or AliasDel to remove the alias itself
 func (r *RediSearch) DropIndex(ctx stdContext.Context, name string, purgeIndexData bool) error {
+	index, err := r.resolveIndexName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if index != name {
+		return aliasDropError(name, index)
+	}
+	return r.dropIndex(ctx, index, purgeIndexData)
+}
+
+func (r *RediSearch) dropIndex(ctx stdContext.Context, index string, purgeIndexData bool) error {
 	args := []interface{}{
 		"FT.DROPINDEX",
-		name,
+		index,
 	}
 	if purgeIndexData {
 		args = append(args, "DD")
@@ -386,7 +651,7 @@ func (r *RediSearch) DropIndex(ctx stdContext.Context, name string, purgeIndexDa
 	return err
 }
 
-// IndexExists return true if the index exists
+// IndexExists return true if the index (or an alias with the given name) exists
 func (r *RediSearch) IndexExists(ctx stdContext.Context, name string) (bool, error) {
 	args := []interface{}{
 		"FT.INFO",
@@ -395,7 +660,7 @@ func (r *RediSearch) IndexExists(ctx stdContext.Context, name string) (bool, err
 	do := r.client.Do(ctx, args...)
 	_, err := do.Result()
 	if err != nil {
-		if strings.Contains(strings.ToLower(err.Error()), "unknown index") {
+		if isUnknownIndex(err) {
 			return false, nil
 		}
 		return false, err
@@ -403,114 +668,241 @@ func (r *RediSearch) IndexExists(ctx stdContext.Context, name string) (bool, err
 	return true, nil
 }
 
-// parseSearchResults into the given list of structs or maps
-func parseSearchResults(raw interface{}, out interface{}) (int64, error) {
-	v := reflect.ValueOf(out)
-	if v.Kind() == reflect.Invalid {
-		return 0, errors.New("invalid {out} type")
-	}
-	if v.Kind() != reflect.Ptr {
-		return 0, errors.New("{out} arg must be a pointer")
+// parseSearchResults into the given list of structs or maps.
+// {flags} are the search flags used in the query, they define the layout of the response
+func parseSearchResults(raw interface{}, flags []string, out interface{}, opts DecodeOptions) (int64, error) {
+	if _, err := outSliceValue(out); err != nil {
+		return 0, err
 	}
-	if !v.Elem().CanSet() {
-		return 0, errors.New("using unaddressable value")
+	res, err := parseSearchReply(raw, flags)
+	if err != nil {
+		return 0, err
 	}
-
-	if v.Elem().Kind() != reflect.Slice {
-		return 0, errors.New("{out} arg must reference a slice")
+	res.decode = opts
+	if err := res.Scan(out); err != nil {
+		return 0, err
 	}
+	return res.Total, nil
+}
 
-	// [totalHits hashKey1 [field1 value1 ...] hashKey2 [field1 value1 ...] ....]
+// parseSearchReply into a SearchResult.
+// {flags} are the search flags used in the query, they define the layout of the response
+func parseSearchReply(raw interface{}, flags []string) (*SearchResult, error) {
+	// [totalHits hashKey1 (score) (payload) (sortKey) [field1 value1 ...] hashKey2 ....]
 	resSlice, ok := raw.([]interface{})
 	if !ok {
-		return 0, fmt.Errorf("invalid redis response type: %T", raw)
+		return nil, fmt.Errorf("invalid redis response type: %T", raw)
 	}
-	if len(resSlice) < 3 { // no results
-		return 0, nil
+	if len(resSlice) == 0 {
+		return &SearchResult{}, nil
 	}
 
 	total, ok := resSlice[0].(int64)
 	if !ok {
-		return 0, fmt.Errorf("invalid redis response.total type: %T", resSlice[0])
+		return nil, fmt.Errorf("invalid redis response.total type: %T", resSlice[0])
 	}
 
-	parsedMaps := make([]map[string]string, len(resSlice[1:])/2)
-	for i := 1; i < len(resSlice); i += 2 {
-		docSlice, ok := resSlice[i+1].([]interface{})
+	withScores := hasFlag(flags, SearchFlagWithScores)
+	withPayloads := hasFlag(flags, SearchFlagWithPayloads)
+	withSortKeys := hasFlag(flags, SearchFlagWithSortKeys)
+	withContent := !hasFlag(flags, SearchFlagNoContent)
+
+	docs := make([]Document, 0, len(resSlice)/2)
+	for i := 1; i < len(resSlice); {
+		var doc Document
+		key, ok := resSlice[i].(string)
 		if !ok {
-			return 0, fmt.Errorf("invalid redis response hash value type: %T", resSlice[i+1])
+			return nil, fmt.Errorf("invalid redis response key type: %T", resSlice[i])
+		}
+		doc.Key = key
+		i++
+
+		if withScores {
+			if i >= len(resSlice) {
+				return nil, errors.New("invalid redis response: missing document score")
+			}
+			score, err := parseScore(resSlice[i])
+			if err != nil {
+				return nil, err
+			}
+			doc.Score = score
+			i++
 		}
+		if withPayloads {
+			if i >= len(resSlice) {
+				return nil, errors.New("invalid redis response: missing document payload")
+			}
+			if payload, ok := resSlice[i].(string); ok {
+				doc.Payload = payload
+			}
+			i++
+		}
+		if withSortKeys {
+			if i >= len(resSlice) {
+				return nil, errors.New("invalid redis response: missing document sort key")
+			}
+			if sortKey, ok := resSlice[i].(string); ok {
+				doc.SortKey = sortKey
+			}
+			i++
+		}
+		if withContent {
+			if i >= len(resSlice) {
+				return nil, errors.New("invalid redis response: missing document fields")
+			}
+			fields, err := parseDocumentFields(resSlice[i])
+			if err != nil {
+				return nil, err
+			}
+			doc.Fields = fields
+			i++
+		}
+		docs = append(docs, doc)
+	}
+	return &SearchResult{Total: total, Docs: docs}, nil
+}
 
-		m := make(map[string]string, len(docSlice)/2)
-		for j := 0; j < len(docSlice); j += 2 {
-			field, value := docSlice[j].(string), docSlice[j+1].(string)
+// parseDocumentFields ([field1 value1 ...]) into a map
+func parseDocumentFields(raw interface{}) (map[string]string, error) {
+	if raw == nil { // document expired or deleted after being matched
+		return map[string]string{}, nil
+	}
+	docSlice, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid redis response hash value type: %T", raw)
+	}
+
+	m := make(map[string]string, len(docSlice)/2)
+	for j := 0; j+1 < len(docSlice); j += 2 {
+		field, ok := docSlice[j].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid redis response field type: %T", docSlice[j])
+		}
+		switch value := docSlice[j+1].(type) {
+		case string:
 			m[field] = value
+		case nil:
+		default:
+			m[field] = fmt.Sprint(value)
 		}
+	}
+	return m, nil
+}
+
+func parseScore(raw interface{}) (float64, error) {
+	switch score := raw.(type) {
+	case string:
+		return strconv.ParseFloat(score, 64)
+	case int64:
+		return float64(score), nil
+	case float64:
+		return score, nil
+	case []interface{}: // EXPLAINSCORE: [score [explanation ...]]
+		if len(score) != 0 {
+			return parseScore(score[0])
+		}
+	}
+	return 0, fmt.Errorf("invalid redis response score type: %T", raw)
+}
 
-		ii := i - 1
-		if ii != 0 {
-			ii /= 2
+// hasFlag return true if {flag} is present in {flags} (case insensitive)
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if strings.EqualFold(f, flag) {
+			return true
 		}
-		parsedMaps[ii] = m
 	}
+	return false
+}
 
+// outSliceValue validate {out} is a settable pointer to a slice and return its reflect value
+func outSliceValue(out interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(out)
+	if v.Kind() == reflect.Invalid {
+		return v, errors.New("invalid {out} type")
+	}
+	if v.Kind() != reflect.Ptr {
+		return v, errors.New("{out} arg must be a pointer")
+	}
+	if !v.Elem().CanSet() {
+		return v, errors.New("using unaddressable value")
+	}
+
+	if v.Elem().Kind() != reflect.Slice {
+		return v, errors.New("{out} arg must reference a slice")
+	}
+	return v, nil
+}
+
+// decodeMaps into v (pointer to a slice of structs or maps).
+// {keys} is optional, if set it must have the same length of {parsedMaps} and is used to populate the struct field tagged as id.
+// Values that can not be decoded are returned as warnings (and logged), or as error if opts.Strict is set
+func decodeMaps(parsedMaps []map[string]string, keys []string, v reflect.Value, opts DecodeOptions) ([]*DecodeError, error) {
 	// avoid type parsing if {out} is the same type of parsed maps
 	if v.Elem().Type().AssignableTo(reflect.TypeOf(parsedMaps)) {
 		v.Elem().Set(reflect.ValueOf(parsedMaps))
-		return total, nil
+		return nil, nil
 	}
 
+	var warnings []*DecodeError
 	switch t := v.Elem().Type().Elem(); t.Kind() {
 	case reflect.Struct:
-		fieldsIndexMap := make(map[string]int, t.NumField())
-		for i := 0; i < t.NumField(); i++ {
-			key := t.Field(i).Name
-			if tag := t.Field(i).Tag.Get("json"); tag != "" {
-				tagSlice := strings.Split(tag, ",")
-				if tagSlice[0] != "" {
-					key = tagSlice[0]
-				}
-
-			}
-			if key != "" && key != "-" {
-				fieldsIndexMap[key] = i
+		fields := structFields(t)
+		var idField *structField
+		for i, field := range fields {
+			if field.id {
+				idField = &fields[i]
 			}
 		}
 
 		e := initValueAndGetElem(v, len(parsedMaps))
 		for i, m := range parsedMaps {
 			sliceItemToSet := e.Index(i)
-			for k, v := range m {
-				fieldIndex, ok := fieldsIndexMap[k]
-				if !ok {
+			var key string
+			if keys != nil {
+				key = keys[i]
+			}
+			// documents indexed ON JSON are returned as a single "$" field holding the whole document
+			if doc, ok := m[jsonRootPath]; ok {
+				if err := json.Unmarshal([]byte(doc), sliceItemToSet.Addr().Interface()); err != nil {
+					decodeErr := &DecodeError{Key: key, Field: jsonRootPath, Value: doc, Type: t, Err: fmt.Errorf("invalid JSON document: %w", err)}
+					if opts.Strict {
+						return nil, decodeErr
+					}
+					opts.logger().Printf("redisearch: %s, the value will be ignored", decodeErr)
+					warnings = append(warnings, decodeErr)
+				}
+			}
+			if idField != nil && keys != nil {
+				if field, ok := fieldByIndex(sliceItemToSet, idField.index, true); ok {
+					field.SetString(key)
+				}
+			}
+			for _, structField := range fields {
+				v, ok := m[structField.name]
+				if !ok || structField.id {
 					continue
 				}
-				field := sliceItemToSet.Field(fieldIndex)
-				if _, ok := supportedDataTypes[field.Kind()]; !ok {
-					log.Println(k + ":" + field.Kind().String() + " type is not supported and will be ignored")
-					continue // ignore unsupported type
+				field, ok := fieldByIndex(sliceItemToSet, structField.index, true)
+				if !ok {
+					continue
 				}
-				switch kind := field.Kind(); {
-				case kind == reflect.String:
-					field.SetString(v)
-				case kind >= reflect.Int && kind <= reflect.Uint64:
-					vInt, _ := strconv.ParseInt(v, 10, 64)
-					field.Set(reflect.ValueOf(vInt).Convert(field.Type()))
-				case kind >= reflect.Float32 && kind <= reflect.Float64:
-					vFloat, _ := strconv.ParseFloat(v, 64)
-					field.Set(reflect.ValueOf(vFloat).Convert(field.Type()))
-				case kind == reflect.Bool:
-					vBool := v[0] == 't' || v[0] == '1'
-					field.SetBool(vBool)
+				if err := decodeValue(v, field, structField.opts); err != nil {
+					decodeErr := &DecodeError{Key: key, Field: structField.name, Value: v, Type: field.Type(), Err: err}
+					if opts.Strict {
+						return nil, decodeErr
+					}
+					opts.logger().Printf("redisearch: %s, the value will be ignored", decodeErr)
+					warnings = append(warnings, decodeErr)
 				}
 			}
 		}
 	case reflect.Map:
 		if t.Key().Kind() != reflect.String {
-			return 0, errors.New("map key must be of type string")
+			return nil, errors.New("map key must be of type string")
 		}
 		if t.Elem().Kind() != reflect.String && t.Elem().Kind() != reflect.Interface {
-			return 0, errors.New("map value type must be of type string or interface{}")
+			return nil, errors.New("map value type must be of type string or interface{}")
 		}
 
 		e := initValueAndGetElem(v, len(parsedMaps))
@@ -524,9 +916,9 @@ func parseSearchResults(raw interface{}, out interface{}) (int64, error) {
 			}
 		}
 	default:
-		return 0, errors.New("{out} must be and slice of structs, interface{} or string map")
+		return nil, errors.New("{out} must be and slice of structs, interface{} or string map")
 	}
-	return total, nil
+	return warnings, nil
 }
 
 // init v of kind slice
@@ -538,3 +930,21 @@ func initValueAndGetElem(v reflect.Value, size int) reflect.Value {
 	e.Set(reflect.MakeSlice(e.Type(), size, size))
 	return e
 }
+
+// fieldName return the hash field name of a struct field, taken from the json tag (if set) or the struct field name
+func fieldName(field reflect.StructField) string {
+	if tag := field.Tag.Get("json"); tag != "" {
+		tagSlice := strings.Split(tag, ",")
+		if tagSlice[0] != "" {
+			return tagSlice[0]
+		}
+	}
+	return field.Name
+}
+
+// isIDField return true if the struct field is tagged as the document id (`redisearch:"id"`).
+// Document id fields must be of type string
+func isIDField(field reflect.StructField) bool {
+	tag := strings.Split(field.Tag.Get(tagName), ",")
+	return tag[0] == tagID && field.Type.Kind() == reflect.String
+}
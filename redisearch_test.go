@@ -1,7 +1,17 @@
 package redisearch
 
 import (
+	"bufio"
+	stdContext "context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"io"
+	"net"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -245,7 +255,7 @@ func Test_parseSearchResults(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseSearchResults(tt.args.raw, tt.args.out)
+			got, err := parseSearchResults(tt.args.raw, nil, tt.args.out, DecodeOptions{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseSearchResults() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -284,7 +294,7 @@ func Benchmark_parseSearchResults_map(b *testing.B) {
 			},
 		}
 		var out []map[string]interface{}
-		_, err := parseSearchResults(raw, &out)
+		_, err := parseSearchResults(raw, nil, &out, DecodeOptions{})
 		if err != nil {
 			b.Error(err)
 		}
@@ -321,9 +331,531 @@ func Benchmark_parseSearchResults_struct(b *testing.B) {
 			Score float32 `json:"score"`
 			Another float32 `json:"another"`
 		}
-		_, err := parseSearchResults(raw, &out)
+		_, err := parseSearchResults(raw, nil, &out, DecodeOptions{})
 		if err != nil {
 			b.Error(err)
 		}
 	}
-}
\ No newline at end of file
+}
+func Test_parseSearchReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		flags   []string
+		want    *SearchResult
+		wantErr bool
+	}{
+		{
+			name: "ok: default layout",
+			raw: []interface{}{
+				int64(2),
+				"doc:1", []interface{}{"title", "one"},
+				"doc:2", []interface{}{"title", "two"},
+			},
+			want: &SearchResult{Total: 2, Docs: []Document{
+				{Key: "doc:1", Fields: map[string]string{"title": "one"}},
+				{Key: "doc:2", Fields: map[string]string{"title": "two"}},
+			}},
+		},
+		{
+			name:  "ok: nocontent",
+			raw:   []interface{}{int64(2), "doc:1", "doc:2"},
+			flags: []string{SearchFlagNoContent},
+			want: &SearchResult{Total: 2, Docs: []Document{
+				{Key: "doc:1"},
+				{Key: "doc:2"},
+			}},
+		},
+		{
+			name: "ok: all flags",
+			raw: []interface{}{
+				int64(1),
+				"doc:1", "1.5", "payload", "$sortkey", []interface{}{"title", "one"},
+			},
+			flags: []string{"withscores", SearchFlagWithPayloads, SearchFlagWithSortKeys},
+			want: &SearchResult{Total: 1, Docs: []Document{
+				{Key: "doc:1", Score: 1.5, Payload: "payload", SortKey: "$sortkey", Fields: map[string]string{"title": "one"}},
+			}},
+		},
+		{
+			name: "ok: scores without content, nil payload",
+			raw: []interface{}{
+				int64(1),
+				"doc:1", "2", nil,
+			},
+			flags: []string{SearchFlagNoContent, SearchFlagWithScores, SearchFlagWithPayloads},
+			want: &SearchResult{Total: 1, Docs: []Document{
+				{Key: "doc:1", Score: 2},
+			}},
+		},
+		{
+			name: "ok: only total (LIMIT 0 0)",
+			raw:  []interface{}{int64(10)},
+			want: &SearchResult{Total: 10, Docs: []Document{}},
+		},
+		{
+			name:    "missing fields",
+			raw:     []interface{}{int64(1), "doc:1", "1.5"},
+			flags:   []string{SearchFlagWithScores},
+			wantErr: true,
+		},
+		{
+			name:    "invalid score",
+			raw:     []interface{}{int64(1), "doc:1", "abc", []interface{}{}},
+			flags:   []string{SearchFlagWithScores},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchReply(tt.raw, tt.flags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSearchReply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSearchReply() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchResult_Scan(t *testing.T) {
+	type TestModel struct {
+		ID    string `redisearch:"id"`
+		Title string `json:"title"`
+	}
+	res := &SearchResult{Total: 2, Docs: []Document{
+		{Key: "doc:1", Fields: map[string]string{"title": "one"}},
+		{Key: "doc:2"},
+	}}
+	var out []TestModel
+	if err := res.Scan(&out); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	want := []TestModel{{ID: "doc:1", Title: "one"}, {ID: "doc:2"}}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Scan() out = %+v, want %+v", out, want)
+	}
+}
+
+func TestIndexOptions_Args(t *testing.T) {
+	tests := []struct {
+		name string
+		opts IndexOptions
+		want []interface{}
+	}{
+		{
+			name: "ok: hash by default",
+			opts: IndexOptions{
+				IndexName: "cities",
+				Prefix:    []string{"city:"},
+				Schema: map[string]FieldSchema{
+					"name": {Type: FieldTypeText, Options: []SchemaOpt{SchemaOptSortable()}},
+				},
+			},
+			want: []interface{}{"FT.CREATE", "cities", "ON", "HASH", "PREFIX", 1, "city:", "SCHEMA", "name", "TEXT", "SORTABLE"},
+		},
+		{
+			name: "ok: json with alias",
+			opts: IndexOptions{
+				IndexName: "cities",
+				On:        IndexOnJSON,
+				Schema: map[string]FieldSchema{
+					"$.address.city": {Type: FieldTypeTag, As: "city"},
+				},
+			},
+			want: []interface{}{"FT.CREATE", "cities", "ON", "JSON", "SCHEMA", "$.address.city", "AS", "city", "TAG"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Args(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IndexOptions.Args() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSearchResults_json(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type TestModel struct {
+		ID      string   `redisearch:"id"`
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+	}
+	raw := []interface{}{
+		int64(1),
+		"city:1",
+		[]interface{}{"$", `{"name":"Popayan","tags":["a","b"],"address":{"city":"Popayan"}}`},
+	}
+	var out []TestModel
+	total, err := parseSearchResults(raw, nil, &out, DecodeOptions{})
+	if err != nil {
+		t.Fatalf("parseSearchResults() error = %v", err)
+	}
+	want := []TestModel{{ID: "city:1", Name: "Popayan", Tags: []string{"a", "b"}, Address: Address{City: "Popayan"}}}
+	if total != 1 || !reflect.DeepEqual(out, want) {
+		t.Errorf("parseSearchResults() = %v %+v, want 1 %+v", total, out, want)
+	}
+
+	raw = []interface{}{int64(1), "city:1", []interface{}{"$", `{"name":`}}
+	if _, err := parseSearchResults(raw, nil, &out, DecodeOptions{Strict: true}); err == nil {
+		t.Error("parseSearchResults() expected error for invalid JSON document")
+	}
+}
+
+func Test_paramsArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		dialect int
+		want    []interface{}
+	}{
+		{
+			name: "ok: no params",
+			want: nil,
+		},
+		{
+			name:    "ok: dialect only",
+			dialect: 3,
+			want:    []interface{}{"DIALECT", 3},
+		},
+		{
+			name:   "ok: sorted params with default dialect",
+			params: map[string]interface{}{"name": "john", "age": 30, "vec": []float32{1}},
+			want:   []interface{}{"PARAMS", 6, "age", 30, "name", "john", "vec", []byte{0, 0, 0x80, 0x3f}, "DIALECT", 2},
+		},
+		{
+			name:    "ok: params with higher dialect",
+			params:  map[string]interface{}{"name": "john"},
+			dialect: 4,
+			want:    []interface{}{"PARAMS", 2, "name", "john", "DIALECT", 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paramsArgs(tt.params, tt.dialect); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paramsArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchOptions_Args_params(t *testing.T) {
+	got := SearchOptions{
+		IndexName: "idx",
+		Query:     "@name:$name",
+		Params:    map[string]interface{}{"name": "john doe"},
+		Limit:     &Limit{Offset: 0, Max: 5},
+	}.Args()
+	want := []interface{}{"FT.SEARCH", "idx", "@name:$name", "LIMIT", 0, 5, "PARAMS", 2, "name", "john doe", "DIALECT", 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchOptions.Args() = %v, want %v", got, want)
+	}
+}
+
+func Test_commandString(t *testing.T) {
+	tests := []struct {
+		name string
+		args []interface{}
+		want string
+	}{
+		{
+			name: "search",
+			args: SearchOptions{
+				IndexName: "idx",
+				Query:     "@name:(john doe)",
+				GeoFilter: &GeoFilter{GeoFieldName: "location", Longitude: -76.6, Latitude: 2.45, Radius: 10, Unit: "km"},
+				Limit:     &Limit{Offset: 0, Max: 5},
+			}.Args(),
+			want: `FT.SEARCH idx "@name:(john doe)" GEOFILTER location -76.6 2.45 10 km LIMIT 0 5`,
+		},
+		{
+			name: "create index",
+			args: IndexOptions{
+				IndexName: "idx",
+				Prefix:    []string{"user:"},
+				Schema: map[string]FieldSchema{
+					"name": {Type: FieldTypeText},
+					"tags": {Type: FieldTypeTag, Options: []SchemaOpt{SchemaOptTagSeparator(';')}},
+				},
+			}.Args(),
+			want: `FT.CREATE idx ON HASH PREFIX 1 user: SCHEMA name TEXT tags TAG SEPARATOR ;`,
+		},
+		{
+			name: "escaped values",
+			args: []interface{}{"SET", "", `say "hi"`, "a\nb", []byte{0x00, 0xcd, 0xcc, 0x3d}, "canción", true, float32(0.5), float32(2.45)},
+			want: `SET "" "say \"hi\"" "a\nb" "\x00\xcd\xcc=" canción 1 0.5 2.450000047683716`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandString(tt.args); got != tt.want {
+				t.Errorf("commandString() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRediSearch_Put(t *testing.T) {
+	tests := []struct {
+		name     string
+		override bool
+		want     [][]interface{}
+	}{
+		{
+			name: "ok",
+			want: [][]interface{}{{"hset", "doc:1", "name", "a"}},
+		},
+		{
+			name:     "ok: override in a transaction",
+			override: true,
+			want:     [][]interface{}{{"multi"}, {"del", "doc:1"}, {"hset", "doc:1", "name", "a"}, {"exec"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, rec := newTestClient(nil)
+			if err := r.Put(stdContext.Background(), "doc:1", map[string]string{"name": "a"}, tt.override); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			if got := rec.commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Put() commands = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRediSearch_PutJSON(t *testing.T) {
+	failed := errors.New("ERR new objects must be created at the root")
+	type city struct {
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		replyErr error
+		want     [][]interface{}
+		wantErr  error
+	}{
+		{
+			name:  "ok",
+			key:   "city:1",
+			value: city{Name: "Popayan"},
+			want:  [][]interface{}{{"JSON.SET", "city:1", "$", `{"name":"Popayan"}`}},
+		},
+		{
+			name:     "server error",
+			key:      "city:1",
+			value:    city{Name: "Popayan"},
+			replyErr: failed,
+			want:     [][]interface{}{{"JSON.SET", "city:1", "$", `{"name":"Popayan"}`}},
+			wantErr:  failed,
+		},
+		{
+			name:  "missing key",
+			value: city{Name: "Popayan"},
+		},
+		{
+			name:  "invalid value",
+			key:   "city:1",
+			value: make(chan int),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+				return "OK", tt.replyErr
+			})
+			err := r.PutJSON(stdContext.Background(), tt.key, tt.value)
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("PutJSON() error = nil, want an error")
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PutJSON() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := rec.commands(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PutJSON() commands = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// testRecorder a go-redis hook that records the commands sent (UNWATCH excluded) and answers them using reply, no server is reached
+type testRecorder struct {
+	mu    sync.Mutex
+	cmds  [][]interface{}
+	reply func(args []interface{}) (interface{}, error)
+}
+
+// newTestClient return a client whose commands are handled by the recorder
+func newTestClient(reply func(args []interface{}) (interface{}, error)) (*RediSearch, *testRecorder) {
+	rec := &testRecorder{reply: reply}
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	client.AddHook(rec)
+	return &RediSearch{client: client}, rec
+}
+
+func (h *testRecorder) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h *testRecorder) ProcessHook(redis.ProcessHook) redis.ProcessHook {
+	return func(ctx stdContext.Context, cmd redis.Cmder) error {
+		h.process(cmd)
+		return cmd.Err()
+	}
+}
+
+func (h *testRecorder) ProcessPipelineHook(redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx stdContext.Context, cmds []redis.Cmder) error {
+		var firstErr error
+		for _, cmd := range cmds {
+			h.process(cmd)
+			if err := cmd.Err(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+}
+
+func (h *testRecorder) process(cmd redis.Cmder) {
+	switch strings.ToLower(cmd.Name()) {
+	case "unwatch":
+		return
+	case "multi", "exec":
+		// recorded to check the transaction boundaries, but not answered by reply
+		h.mu.Lock()
+		h.cmds = append(h.cmds, cmd.Args())
+		h.mu.Unlock()
+		return
+	}
+	h.mu.Lock()
+	h.cmds = append(h.cmds, cmd.Args())
+	h.mu.Unlock()
+
+	var val interface{}
+	var err error
+	if h.reply != nil {
+		val, err = h.reply(cmd.Args())
+	}
+	if err != nil {
+		cmd.SetErr(err)
+		return
+	}
+	switch cmd := cmd.(type) {
+	case *redis.Cmd:
+		cmd.SetVal(val)
+	case *redis.IntCmd:
+		i, _ := val.(int64)
+		cmd.SetVal(i)
+	case *redis.StringCmd:
+		if val == nil {
+			cmd.SetErr(redis.Nil)
+			return
+		}
+		cmd.SetVal(val.(string))
+	case *redis.StatusCmd:
+		cmd.SetVal("OK")
+	}
+}
+
+// commands return the recorded commands
+func (h *testRecorder) commands() [][]interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.cmds
+}
+
+// wireServer a fake RESP server reached through net.Pipe, so the commands go through the go-redis writer.
+// It records the commands as sent on the wire and answers HSET with an integer and anything else with OK
+type wireServer struct {
+	mu   sync.Mutex
+	cmds [][]string
+}
+
+// newWireClient return a client connected to a fake RESP server
+func newWireClient() (*RediSearch, *wireServer) {
+	srv := &wireServer{}
+	client := redis.NewClient(&redis.Options{
+		DisableIndentity: true,
+		Dialer: func(ctx stdContext.Context, network, addr string) (net.Conn, error) {
+			conn, server := net.Pipe()
+			go srv.serve(server)
+			return conn, nil
+		},
+	})
+	return &RediSearch{client: client}, srv
+}
+
+func (s *wireServer) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		cmd, err := readWireCommand(rd)
+		if err != nil {
+			return
+		}
+		reply := "+OK\r\n"
+		switch strings.ToLower(cmd[0]) {
+		case "hello":
+			// unsupported, so go-redis falls back to RESP2
+			reply = "-ERR unknown command\r\n"
+		case "hset":
+			reply = ":1\r\n"
+		}
+		if !strings.EqualFold(cmd[0], "hello") {
+			s.mu.Lock()
+			s.cmds = append(s.cmds, cmd)
+			s.mu.Unlock()
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+// readWireCommand read a RESP array of bulk strings
+func readWireCommand(rd *bufio.Reader) ([]string, error) {
+	readLine := func(prefix byte) (int, error) {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		if len(line) < 3 || line[0] != prefix {
+			return 0, fmt.Errorf("unexpected line %q", line)
+		}
+		return strconv.Atoi(strings.TrimSpace(line[1:]))
+	}
+	n, err := readLine('*')
+	if err != nil {
+		return nil, err
+	}
+	cmd := make([]string, n)
+	for i := range cmd {
+		size, err := readLine('$')
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(rd, buf); err != nil {
+			return nil, err
+		}
+		cmd[i] = string(buf[:size])
+	}
+	return cmd, nil
+}
+
+// commands return the commands received (HELLO excluded)
+func (s *wireServer) commands() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cmds
+}
@@ -0,0 +1,141 @@
+package redisearch
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schemaFieldTypes maps the field types accepted in the redisearch struct tag to FieldType* constants
+var schemaFieldTypes = map[string]string{
+	"text":    FieldTypeText,
+	"tag":     FieldTypeTag,
+	"numeric": FieldTypeNumeric,
+	"geo":     FieldTypeGeo,
+}
+
+// schemaOptionFieldTypes maps the struct tag options that only apply to one field type to the FieldType* constant,
+// FT.CREATE rejects them on any other type
+var schemaOptionFieldTypes = map[string]string{
+	"weight":    FieldTypeText,
+	"nostem":    FieldTypeText,
+	"phonetic":  FieldTypeText,
+	"separator": FieldTypeTag,
+}
+
+// SchemaFromStruct build an index schema from the `redisearch` tags of the given struct (or pointer to struct).
+// The field name is taken from the json tag (if set) or the struct field name, the same way Put and Search do,
+// including promoted fields of embedded structs and flattened fields of nested structs (ie: address.city).
+// Fields without a redisearch tag are not indexed. The tag format is `redisearch:"type,option,option=value"` where type
+// is one of text, tag, numeric or geo and the supported options are (an error is returned if the option does not apply to the type):
+// * weight={float} see SchemaOptWeight (text only)
+// * sortable see SchemaOptSortable
+// * nostem see SchemaOptNoStem (text only)
+// * noindex see SchemaOptNoIndex
+// * phonetic={matcher} see SchemaOptPhonetic (text only)
+// * separator={character} see SchemaOptTagSeparator (tag only). Comma can not be used as it is the tag options separator, but it is the default anyway
+// * as={name} see FieldSchema.As
+// * time={format} encoding of time.Time values, see CodecOptions.TimeFormat
+//
+// Tags without a type (ie: `redisearch:",time=unix"`) only set encoding options, see CodecOptions.
+//
+//	type City struct {
+//		ID         string `redisearch:"id"`
+//		Name       string `json:"name" redisearch:"text,weight=2,sortable"`
+//		Tags       string `json:"tags" redisearch:"tag,separator=;"`
+//		Population int    `json:"population" redisearch:"numeric,sortable"`
+//	}
+func SchemaFromStruct(v interface{}) (map[string]FieldSchema, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, errors.New("invalid {v} type")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("{v} arg must be a struct or a pointer to struct")
+	}
+
+	schema := make(map[string]FieldSchema)
+	for _, field := range structFields(t) {
+		tag, ok := field.field.Tag.Lookup(tagName)
+		if !ok || tag == "" || tag == "-" || field.id {
+			continue
+		}
+		if strings.TrimSpace(strings.Split(tag, ",")[0]) == "" {
+			continue // only encoding options (ie: `redisearch:",time=unix"`), not indexed
+		}
+		fieldSchema, err := parseSchemaTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.field.Name, err)
+		}
+		schema[field.name] = fieldSchema
+	}
+	return schema, nil
+}
+
+// parseSchemaTag parse a redisearch struct tag ("type,option,option=value").
+// FT.CREATE expects the type specific options (WEIGHT, NOSTEM, PHONETIC, SEPARATOR) before SORTABLE and NOINDEX,
+// so those are always set last, whatever the order in the tag
+func parseSchemaTag(tag string) (FieldSchema, error) {
+	parts := strings.Split(tag, ",")
+	fieldType, ok := schemaFieldTypes[strings.ToLower(strings.TrimSpace(parts[0]))]
+	if !ok {
+		return FieldSchema{}, fmt.Errorf("unsupported field type %q", parts[0])
+	}
+
+	fieldSchema := FieldSchema{Type: fieldType}
+	var sortable, noIndex bool
+	for _, part := range parts[1:] {
+		option, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		option = strings.ToLower(option)
+		if optionType, ok := schemaOptionFieldTypes[option]; ok && optionType != fieldType {
+			return FieldSchema{}, fmt.Errorf("option %q is not supported by %s fields", option, fieldType)
+		}
+		switch option {
+		case "weight":
+			weight, err := strconv.ParseFloat(value, 32)
+			if err != nil {
+				return FieldSchema{}, fmt.Errorf("invalid weight %q", value)
+			}
+			fieldSchema.Options = append(fieldSchema.Options, SchemaOptWeight(float32(weight)))
+		case "sortable":
+			sortable = true
+		case "nostem":
+			fieldSchema.Options = append(fieldSchema.Options, SchemaOptNoStem())
+		case "noindex":
+			noIndex = true
+		case "phonetic":
+			if value == "" {
+				return FieldSchema{}, errors.New("missing phonetic matcher")
+			}
+			fieldSchema.Options = append(fieldSchema.Options, SchemaOptPhonetic(value))
+		case "separator":
+			if len(value) != 1 {
+				return FieldSchema{}, fmt.Errorf("invalid separator %q, it must be a single character", value)
+			}
+			fieldSchema.Options = append(fieldSchema.Options, SchemaOptTagSeparator(value[0]))
+		case "as":
+			fieldSchema.As = value
+		case "time":
+			switch strings.ToLower(value) {
+			case TimeFormatRFC3339, TimeFormatUnix, TimeFormatUnixMilli:
+			default:
+				return FieldSchema{}, fmt.Errorf("invalid time format %q", value)
+			}
+		case "":
+		default:
+			return FieldSchema{}, fmt.Errorf("unsupported option %q", option)
+		}
+	}
+	if sortable {
+		fieldSchema.Options = append(fieldSchema.Options, SchemaOptSortable())
+	}
+	if noIndex {
+		fieldSchema.Options = append(fieldSchema.Options, SchemaOptNoIndex())
+	}
+	return fieldSchema, nil
+}
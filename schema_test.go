@@ -0,0 +1,155 @@
+package redisearch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSchemaFromStruct(t *testing.T) {
+	type City struct {
+		ID         string  `redisearch:"id"`
+		Name       string  `json:"name" redisearch:"text,weight=2,sortable,nostem"`
+		Tags       string  `json:"tags" redisearch:"tag,sortable,separator=;"`
+		Population int     `json:"population,omitempty" redisearch:"numeric,noindex,sortable"`
+		Location   string  `redisearch:"geo"`
+		Phonetic   string  `json:"phonetic" redisearch:"TEXT,phonetic=dm:es,as=sound"`
+		NotIndexed float64 `json:"not_indexed"`
+		Ignored    string  `json:"-" redisearch:"text"`
+	}
+	tests := []struct {
+		name    string
+		v       interface{}
+		want    map[string]FieldSchema
+		wantErr bool
+	}{
+		{
+			name: "ok: struct pointer",
+			v:    &City{},
+			want: map[string]FieldSchema{
+				"name":       {Type: FieldTypeText, Options: []SchemaOpt{SchemaOptWeight(2), SchemaOptNoStem(), SchemaOptSortable()}},
+				"tags":       {Type: FieldTypeTag, Options: []SchemaOpt{SchemaOptTagSeparator(';'), SchemaOptSortable()}},
+				"population": {Type: FieldTypeNumeric, Options: []SchemaOpt{SchemaOptSortable(), SchemaOptNoIndex()}},
+				"Location":   {Type: FieldTypeGeo},
+				"phonetic":   {Type: FieldTypeText, As: "sound", Options: []SchemaOpt{SchemaOptPhonetic("dm:es")}},
+			},
+		},
+		{
+			name: "unsupported type",
+			v: struct {
+				A string `redisearch:"blob"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "unsupported option",
+			v: struct {
+				A string `redisearch:"text,foo"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "ok: time options",
+			v: struct {
+				Created time.Time `json:"created" redisearch:"numeric,sortable,time=unix"`
+				Updated time.Time `json:"updated" redisearch:",time=unixmilli"`
+			}{},
+			want: map[string]FieldSchema{
+				"created": {Type: FieldTypeNumeric, Options: []SchemaOpt{SchemaOptSortable()}},
+			},
+		},
+		{
+			name: "invalid time format",
+			v: struct {
+				A time.Time `redisearch:"numeric,time=days"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "invalid separator",
+			v: struct {
+				A string `redisearch:"tag,separator=ab"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "weight on numeric",
+			v: struct {
+				A int `redisearch:"numeric,sortable,weight=2"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "separator on text",
+			v: struct {
+				A string `redisearch:"text,separator=;"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "nostem on tag",
+			v: struct {
+				A string `redisearch:"tag,nostem"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "phonetic on numeric",
+			v: struct {
+				A int `redisearch:"numeric,phonetic=dm:en"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "weight on geo",
+			v: struct {
+				A string `redisearch:"geo,weight=1"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name:    "not a struct",
+			v:       map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:    "nil",
+			v:       nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SchemaFromStruct(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SchemaFromStruct() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SchemaFromStruct() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSchemaTag_optionsOrder(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []interface{}
+	}{
+		{tag: "text,weight=2,sortable,nostem", want: []interface{}{"f", "TEXT", "WEIGHT", "2.0", "NOSTEM", "SORTABLE"}},
+		{tag: "tag,sortable,separator=;", want: []interface{}{"f", "TAG", "SEPARATOR", ";", "SORTABLE"}},
+		{tag: "text,noindex,sortable,phonetic=dm:en", want: []interface{}{"f", "TEXT", "PHONETIC", "dm:en", "SORTABLE", "NOINDEX"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			schema, err := parseSchemaTag(tt.tag)
+			if err != nil {
+				t.Fatalf("parseSchemaTag() error = %v", err)
+			}
+			if got := schemaFieldArgs("f", schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schemaFieldArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
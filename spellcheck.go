@@ -0,0 +1,188 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type SpellCheckOptions struct {
+	// Distance maximum Levenshtein distance for spelling suggestions (default: 1, max: 4)
+	Distance int
+	// Include custom dictionaries (see DictAdd) used as a source of suggestions
+	Include []string
+	// Exclude custom dictionaries of terms that must not be reported as misspelled
+	Exclude []string
+	// Dialect the query dialect version. See SearchOptions.Dialect
+	Dialect int
+}
+
+// SpellSuggestion a spelling suggestion for a misspelled term
+type SpellSuggestion struct {
+	Suggestion string
+	// Score the normalized score of the suggestion, the number of documents containing it over the total number of documents
+	Score float64
+}
+
+// DidYouMean spelling suggestions for a query that returned no results
+type DidYouMean struct {
+	// Query the original query with each misspelled term replaced by its best suggestion
+	Query string
+	// Suggestions spelling suggestions of each misspelled term
+	Suggestions map[string][]SpellSuggestion
+}
+
+// SpellCheck perform spelling correction on a query, returning the suggestions of each misspelled term (sorted by score, higher first).
+// Terms with no suggestions are included with an empty list
+func (r *RediSearch) SpellCheck(ctx stdContext.Context, index, query string, opts SpellCheckOptions) (map[string][]SpellSuggestion, error) {
+	if index == "" || query == "" {
+		return nil, errors.New("missing required index or query")
+	}
+	args := []interface{}{"FT.SPELLCHECK", index, query}
+	if opts.Distance > 0 {
+		args = append(args, "DISTANCE", opts.Distance)
+	}
+	for _, dict := range opts.Include {
+		args = append(args, "TERMS", "INCLUDE", dict)
+	}
+	for _, dict := range opts.Exclude {
+		args = append(args, "TERMS", "EXCLUDE", dict)
+	}
+	if opts.Dialect > 0 {
+		args = append(args, "DIALECT", opts.Dialect)
+	}
+	do := r.client.Do(ctx, args...)
+	res, err := do.Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseSpellCheck(res)
+}
+
+// SearchWithSpellCheck run the search and, if it has no results, spell check the query. didYouMean is nil if the search had results
+// or no suggestions were found, otherwise didYouMean.Query can be used to search again.
+// Queries using Params or Vector are not spell checked, the query is a template and the user input is in the parameters
+func (r *RediSearch) SearchWithSpellCheck(ctx stdContext.Context, opts SearchOptions, spellOpts SpellCheckOptions, out interface{}) (total int64, didYouMean *DidYouMean, err error) {
+	total, err = r.Search(ctx, opts, out)
+	if err != nil || total != 0 || opts.Query == "" || len(opts.Params) != 0 || opts.Vector != nil {
+		return total, nil, err
+	}
+	if spellOpts.Dialect == 0 {
+		spellOpts.Dialect = opts.Dialect
+	}
+	suggestions, err := r.SpellCheck(ctx, opts.IndexName, opts.Query, spellOpts)
+	if err != nil {
+		return 0, nil, err
+	}
+	return 0, newDidYouMean(opts.Query, suggestions), nil
+}
+
+// newDidYouMean replace each misspelled term of the query by its best suggestion. It returns nil if there are no suggestions.
+// Only whole words are replaced (case insensitive), words are runs of unicode letters, digits and underscores so accented terms
+// (ie: bogotá) are matched too. Each word is replaced at most once, so overlapping suggestions do not affect each other
+func newDidYouMean(query string, suggestions map[string][]SpellSuggestion) *DidYouMean {
+	terms := make([]string, 0, len(suggestions))
+	for term := range suggestions {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	replacements := make(map[string]string, len(terms))
+	for _, term := range terms {
+		key := strings.ToLower(term)
+		if _, ok := replacements[key]; ok || len(suggestions[term]) == 0 {
+			continue
+		}
+		replacements[key] = suggestions[term][0].Suggestion
+	}
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	var corrected strings.Builder
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			corrected.WriteRune(runes[i])
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+		word := string(runes[start:i])
+		if replacement, ok := replacements[strings.ToLower(word)]; ok {
+			word = replacement
+		}
+		corrected.WriteString(word)
+	}
+	return &DidYouMean{Query: corrected.String(), Suggestions: suggestions}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// parseSpellCheck parse the FT.SPELLCHECK reply ([[TERM term [[score suggestion] ...]] ...])
+func parseSpellCheck(raw interface{}) (map[string][]SpellSuggestion, error) {
+	resSlice, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid redis response type: %T", raw)
+	}
+	res := make(map[string][]SpellSuggestion, len(resSlice))
+	for _, rawTerm := range resSlice {
+		term, ok := rawTerm.([]interface{})
+		if !ok || len(term) != 3 {
+			return nil, fmt.Errorf("invalid redis response term: %v", rawTerm)
+		}
+		suggestions := []SpellSuggestion{}
+		rawSuggestions, _ := term[2].([]interface{})
+		for _, rawSuggestion := range rawSuggestions {
+			suggestion, ok := rawSuggestion.([]interface{})
+			if !ok || len(suggestion) != 2 {
+				return nil, fmt.Errorf("invalid redis response suggestion: %v", rawSuggestion)
+			}
+			score, err := parseScore(suggestion[0])
+			if err != nil {
+				return nil, err
+			}
+			suggestions = append(suggestions, SpellSuggestion{Suggestion: infoString(suggestion[1]), Score: score})
+		}
+		sort.SliceStable(suggestions, func(i, j int) bool {
+			return suggestions[i].Score > suggestions[j].Score
+		})
+		res[infoString(term[1])] = suggestions
+	}
+	return res, nil
+}
+
+// DictAdd add terms to a dictionary. It returns the number of new terms that were added
+func (r *RediSearch) DictAdd(ctx stdContext.Context, dict string, terms ...string) (int64, error) {
+	return r.dictCommand(ctx, "FT.DICTADD", dict, terms)
+}
+
+// DictDel delete terms from a dictionary. It returns the number of terms that were deleted
+func (r *RediSearch) DictDel(ctx stdContext.Context, dict string, terms ...string) (int64, error) {
+	return r.dictCommand(ctx, "FT.DICTDEL", dict, terms)
+}
+
+// DictDump return all the terms in the given dictionary
+func (r *RediSearch) DictDump(ctx stdContext.Context, dict string) ([]string, error) {
+	do := r.client.Do(ctx, "FT.DICTDUMP", dict)
+	return do.StringSlice()
+}
+
+func (r *RediSearch) dictCommand(ctx stdContext.Context, command, dict string, terms []string) (int64, error) {
+	if dict == "" || len(terms) == 0 {
+		return 0, errors.New("missing required dictionary or terms")
+	}
+	args := []interface{}{command, dict}
+	for _, term := range terms {
+		args = append(args, term)
+	}
+	do := r.client.Do(ctx, args...)
+	return do.Int64()
+}
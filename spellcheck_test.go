@@ -0,0 +1,182 @@
+package redisearch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_parseSpellCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    map[string][]SpellSuggestion
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			raw: []interface{}{
+				[]interface{}{"TERM", "popayna", []interface{}{
+					[]interface{}{"0.5", "popayan"},
+					[]interface{}{"0.25", "payan"},
+				}},
+				[]interface{}{"TERM", "xyz", []interface{}{}},
+			},
+			want: map[string][]SpellSuggestion{
+				"popayna": {{Suggestion: "popayan", Score: 0.5}, {Suggestion: "payan", Score: 0.25}},
+				"xyz":     {},
+			},
+		},
+		{
+			name: "ok: sorted by score",
+			raw: []interface{}{
+				[]interface{}{"TERM", "popayna", []interface{}{
+					[]interface{}{"0.25", "payan"},
+					[]interface{}{"0.5", "popayan"},
+					[]interface{}{"0.25", "papaya"},
+				}},
+			},
+			want: map[string][]SpellSuggestion{
+				"popayna": {{Suggestion: "popayan", Score: 0.5}, {Suggestion: "payan", Score: 0.25}, {Suggestion: "papaya", Score: 0.25}},
+			},
+		},
+		{
+			name: "ok: no misspelled terms",
+			raw:  []interface{}{},
+			want: map[string][]SpellSuggestion{},
+		},
+		{
+			name:    "invalid term",
+			raw:     []interface{}{[]interface{}{"TERM", "popayna"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid suggestion",
+			raw:     []interface{}{[]interface{}{"TERM", "popayna", []interface{}{[]interface{}{"0.5"}}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSpellCheck(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSpellCheck() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSpellCheck() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newDidYouMean(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		suggestions map[string][]SpellSuggestion
+		want        string
+		wantNil     bool
+	}{
+		{
+			name:  "replace best suggestion",
+			query: "@name:Popayna colombia",
+			suggestions: map[string][]SpellSuggestion{
+				"popayna": {{Suggestion: "popayan", Score: 0.5}, {Suggestion: "payan", Score: 0.25}},
+			},
+			want: "@name:popayan colombia",
+		},
+		{
+			name:  "whole words only",
+			query: "cal calle",
+			suggestions: map[string][]SpellSuggestion{
+				"cal": {{Suggestion: "cali", Score: 1}},
+			},
+			want: "cali calle",
+		},
+		{
+			name:  "non ASCII terms",
+			query: "popayán bogota Ñoño",
+			suggestions: map[string][]SpellSuggestion{
+				"popayán": {{Suggestion: "popayan", Score: 1}},
+				"bogota":  {{Suggestion: "bogotá", Score: 1}},
+				"ñoño":    {{Suggestion: "niño", Score: 1}},
+			},
+			want: "popayan bogotá niño",
+		},
+		{
+			name:  "non ASCII word boundaries",
+			query: "cali cálido",
+			suggestions: map[string][]SpellSuggestion{
+				"cál":  {{Suggestion: "cal", Score: 1}},
+				"cali": {{Suggestion: "calí", Score: 1}},
+			},
+			want: "calí cálido",
+		},
+		{
+			name:  "overlapping suggestions are not chained",
+			query: "cal cali",
+			suggestions: map[string][]SpellSuggestion{
+				"cal":  {{Suggestion: "cali", Score: 1}},
+				"cali": {{Suggestion: "calima", Score: 1}},
+			},
+			want: "cali calima",
+		},
+		{
+			name:        "no suggestions",
+			query:       "xyz",
+			suggestions: map[string][]SpellSuggestion{"xyz": {}},
+			wantNil:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newDidYouMean(tt.query, tt.suggestions)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("newDidYouMean() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Query != tt.want {
+				t.Errorf("newDidYouMean() = %+v, want query %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRediSearch_SearchWithSpellCheck(t *testing.T) {
+	newClient := func() (*RediSearch, *testRecorder) {
+		return newTestClient(func(args []interface{}) (interface{}, error) {
+			if args[0] == "FT.SPELLCHECK" {
+				return []interface{}{[]interface{}{"TERM", "popayna", []interface{}{[]interface{}{"0.5", "popayan"}}}}, nil
+			}
+			return []interface{}{int64(0)}, nil
+		})
+	}
+	ctx := context.Background()
+	var out []map[string]string
+
+	r, rec := newClient()
+	_, didYouMean, err := r.SearchWithSpellCheck(ctx, SearchOptions{IndexName: "cities", Query: "popayna", Dialect: 2}, SpellCheckOptions{}, &out)
+	if err != nil {
+		t.Fatalf("SearchWithSpellCheck() error = %v", err)
+	}
+	if didYouMean == nil || didYouMean.Query != "popayan" {
+		t.Errorf("SearchWithSpellCheck() didYouMean = %+v, want popayan", didYouMean)
+	}
+	if got := rec.commands(); len(got) != 2 || !reflect.DeepEqual(got[1], []interface{}{"FT.SPELLCHECK", "cities", "popayna", "DIALECT", 2}) {
+		t.Errorf("SearchWithSpellCheck() commands = %v", got)
+	}
+
+	// the query is a template, the user input is in the parameters
+	r, rec = newClient()
+	opts := SearchOptions{IndexName: "cities", Query: "@name:$name", Params: map[string]interface{}{"name": "popayna"}}
+	_, didYouMean, err = r.SearchWithSpellCheck(ctx, opts, SpellCheckOptions{}, &out)
+	if err != nil || didYouMean != nil {
+		t.Errorf("SearchWithSpellCheck() = %+v, %v, want no spell check", didYouMean, err)
+	}
+	if got := rec.commands(); len(got) != 1 || got[0][0] != "FT.SEARCH" {
+		t.Errorf("SearchWithSpellCheck() commands = %v, want only FT.SEARCH", got)
+	}
+}
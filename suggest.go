@@ -0,0 +1,206 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+type SugAddOptions struct {
+	// Incr increment the existing entry of the suggestion by the given score, instead of replacing the score
+	Incr bool
+	// Payload saves an extra payload with the suggestion, that can be fetched using SugGetOptions.WithPayloads
+	Payload string
+}
+
+type SugGetOptions struct {
+	// Fuzzy do a fuzzy prefix search, including prefixes at Levenshtein distance of 1 from the prefix sent
+	Fuzzy bool
+	// Max limit the results to a maximum of {Max} (default: 5)
+	Max int
+	// WithScores also return the score of each suggestion
+	WithScores bool
+	// WithPayloads return optional payloads saved along with the suggestions
+	WithPayloads bool
+}
+
+// Suggestion an autocomplete suggestion
+type Suggestion struct {
+	String string
+	// Score only set if SugGetOptions.WithScores is used
+	Score float64
+	// Payload only set if SugGetOptions.WithPayloads is used
+	Payload string
+}
+
+type SugLoadOptions struct {
+	// IndexName the index to read the values from. REQUIRED
+	IndexName string
+	// FieldName the field holding the suggestions. Each distinct value is added with a score equal to the number of documents having it. REQUIRED
+	FieldName string
+	// Query filter the documents to read. Defaults to * (all documents)
+	Query string
+	// BatchSize number of suggestions read and written per batch. Defaults to 1000
+	BatchSize int
+}
+
+// SugAdd add a suggestion string to an auto-complete suggestion dictionary. It returns the current size of the dictionary
+func (r *RediSearch) SugAdd(ctx stdContext.Context, key, suggestion string, score float64, opts SugAddOptions) (int64, error) {
+	if key == "" || suggestion == "" {
+		return 0, errors.New("missing required key or suggestion")
+	}
+	do := r.client.Do(ctx, sugAddArgs(key, suggestion, score, opts)...)
+	return do.Int64()
+}
+
+// SugGet get completion suggestions for a prefix
+func (r *RediSearch) SugGet(ctx stdContext.Context, key, prefix string, opts SugGetOptions) ([]Suggestion, error) {
+	if key == "" || prefix == "" {
+		return nil, errors.New("missing required key or prefix")
+	}
+	args := []interface{}{"FT.SUGGET", key, prefix}
+	if opts.Fuzzy {
+		args = append(args, "FUZZY")
+	}
+	if opts.WithScores {
+		args = append(args, "WITHSCORES")
+	}
+	if opts.WithPayloads {
+		args = append(args, "WITHPAYLOADS")
+	}
+	if opts.Max > 0 {
+		args = append(args, "MAX", opts.Max)
+	}
+	do := r.client.Do(ctx, args...)
+	res, err := do.Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseSuggestions(res, opts)
+}
+
+// SugDel delete a string from a suggestion dictionary. It returns false if the string was not found
+func (r *RediSearch) SugDel(ctx stdContext.Context, key, suggestion string) (bool, error) {
+	do := r.client.Do(ctx, "FT.SUGDEL", key, suggestion)
+	deleted, err := do.Int64()
+	return deleted == 1, err
+}
+
+// SugLen get the size of an auto-complete suggestion dictionary
+func (r *RediSearch) SugLen(ctx stdContext.Context, key string) (int64, error) {
+	do := r.client.Do(ctx, "FT.SUGLEN", key)
+	return do.Int64()
+}
+
+// SugLoad feed the suggestion dictionary {key} with the distinct values of an index field, scored by the number of documents having each value.
+// Values are read using an aggregation cursor and written using pipelines, so big indexes can be loaded without holding all the values in memory.
+// Scores are replaced (not incremented), so loading the same index again refreshes the scores. Suggestions whose value is no longer in the index
+// are not removed from the dictionary: delete the dictionary key (or use SugDel) before loading to drop them. It returns the number of suggestions loaded
+func (r *RediSearch) SugLoad(ctx stdContext.Context, key string, opts SugLoadOptions) (int64, error) {
+	if key == "" || opts.IndexName == "" || opts.FieldName == "" {
+		return 0, errors.New("missing required key, IndexName or FieldName")
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+	cursor, err := r.AggregateWithCursor(ctx, AggregateOptions{
+		IndexName: opts.IndexName,
+		Query:     opts.Query,
+		Steps: []AggregateStep{
+			AggregateLoad(opts.FieldName),
+			AggregateGroupBy([]string{opts.FieldName}, ReducerCount(sugLoadCountAlias)),
+		},
+	}, CursorOptions{Count: batchSize})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	var loaded int64
+	for cursor.Next(ctx) {
+		var rows []map[string]string
+		if err := cursor.Scan(&rows); err != nil {
+			return loaded, err
+		}
+		pipe := r.client.Pipeline()
+		for _, args := range sugLoadArgs(key, opts.FieldName, rows) {
+			pipe.Do(ctx, args...)
+		}
+		cmds, err := pipe.Exec(ctx)
+		if err != nil {
+			return loaded, err
+		}
+		loaded += int64(len(cmds))
+	}
+	return loaded, cursor.Err()
+}
+
+// sugLoadCountAlias name of the number of documents of each value in the SugLoad aggregation
+const sugLoadCountAlias = "__count"
+
+// sugLoadArgs return the FT.SUGADD arguments of a batch of SugLoad aggregation rows, rows without a value are skipped
+func sugLoadArgs(key, fieldName string, rows []map[string]string) [][]interface{} {
+	var cmds [][]interface{}
+	for _, row := range rows {
+		suggestion := row[fieldName]
+		if suggestion == "" {
+			continue
+		}
+		score, _ := strconv.ParseFloat(row[sugLoadCountAlias], 64)
+		cmds = append(cmds, sugAddArgs(key, suggestion, score, SugAddOptions{}))
+	}
+	return cmds
+}
+
+func sugAddArgs(key, suggestion string, score float64, opts SugAddOptions) []interface{} {
+	args := []interface{}{"FT.SUGADD", key, suggestion, score}
+	if opts.Incr {
+		args = append(args, "INCR")
+	}
+	if opts.Payload != "" {
+		args = append(args, "PAYLOAD", opts.Payload)
+	}
+	return args
+}
+
+// parseSuggestions parse the FT.SUGGET reply ([string1 (score1) (payload1) string2 ...])
+func parseSuggestions(raw interface{}, opts SugGetOptions) ([]Suggestion, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	resSlice, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid redis response type: %T", raw)
+	}
+	step := 1
+	if opts.WithScores {
+		step++
+	}
+	if opts.WithPayloads {
+		step++
+	}
+	if len(resSlice)%step != 0 {
+		return nil, fmt.Errorf("invalid redis response length: %d", len(resSlice))
+	}
+
+	suggestions := make([]Suggestion, 0, len(resSlice)/step)
+	for i := 0; i < len(resSlice); i += step {
+		suggestion := Suggestion{String: infoString(resSlice[i])}
+		j := i + 1
+		if opts.WithScores {
+			score, err := parseScore(resSlice[j])
+			if err != nil {
+				return nil, err
+			}
+			suggestion.Score = score
+			j++
+		}
+		if opts.WithPayloads {
+			suggestion.Payload = infoString(resSlice[j])
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,111 @@
+package redisearch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func Test_sugAddArgs(t *testing.T) {
+	got := sugAddArgs("sug", "popayan", 2, SugAddOptions{Incr: true, Payload: "city:1"})
+	want := []interface{}{"FT.SUGADD", "sug", "popayan", float64(2), "INCR", "PAYLOAD", "city:1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sugAddArgs() = %v, want %v", got, want)
+	}
+}
+
+func Test_parseSuggestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		opts    SugGetOptions
+		want    []Suggestion
+		wantErr bool
+	}{
+		{
+			name: "ok: strings only",
+			raw:  []interface{}{"popayan", "pasto"},
+			want: []Suggestion{{String: "popayan"}, {String: "pasto"}},
+		},
+		{
+			name: "ok: with scores and payloads",
+			raw:  []interface{}{"popayan", "2.5", "city:1", "pasto", "1", nil},
+			opts: SugGetOptions{WithScores: true, WithPayloads: true},
+			want: []Suggestion{{String: "popayan", Score: 2.5, Payload: "city:1"}, {String: "pasto", Score: 1}},
+		},
+		{
+			name: "ok: no results",
+			raw:  nil,
+			want: nil,
+		},
+		{
+			name:    "invalid length",
+			raw:     []interface{}{"popayan", "2.5", "pasto"},
+			opts:    SugGetOptions{WithScores: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSuggestions(tt.raw, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSuggestions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSuggestions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sugLoadArgs(t *testing.T) {
+	rows := []map[string]string{
+		{"name": "Popayan", sugLoadCountAlias: "3"},
+		{sugLoadCountAlias: "2"}, // documents without the field
+		{"name": "Cali", sugLoadCountAlias: "1"},
+	}
+	want := [][]interface{}{
+		{"FT.SUGADD", "sug", "Popayan", float64(3)},
+		{"FT.SUGADD", "sug", "Cali", float64(1)},
+	}
+	if got := sugLoadArgs("sug", "name", rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("sugLoadArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestRediSearch_SugLoad(t *testing.T) {
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		switch args[0] {
+		case "FT.AGGREGATE":
+			return []interface{}{
+				[]interface{}{int64(3), []interface{}{"name", "Popayan", "__count", "3"}, []interface{}{"__count", "2"}},
+				int64(5),
+			}, nil
+		case "FT.CURSOR":
+			return []interface{}{[]interface{}{int64(3), []interface{}{"name", "Cali", "__count", "1"}}, int64(0)}, nil
+		}
+		return int64(1), nil
+	})
+	loaded, err := r.SugLoad(context.Background(), "sug", SugLoadOptions{IndexName: "cities", FieldName: "name", BatchSize: 2})
+	if err != nil {
+		t.Fatalf("SugLoad() error = %v", err)
+	}
+	if loaded != 2 {
+		t.Errorf("SugLoad() = %d, want 2", loaded)
+	}
+	want := [][]interface{}{
+		{
+			"FT.AGGREGATE", "cities", "*",
+			"LOAD", 1, "@name",
+			"GROUPBY", 1, "@name", "REDUCE", "COUNT", 0, "AS", "__count",
+			"WITHCURSOR", "COUNT", 2,
+		},
+		{"FT.SUGADD", "sug", "Popayan", float64(3)},
+		{"FT.CURSOR", "READ", "cities", int64(5), "COUNT", 2},
+		{"FT.SUGADD", "sug", "Cali", float64(1)},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SugLoad() commands = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type SynUpdateOptions struct {
+	// SkipInitialScan do not scan and index existing documents, only documents added or modified afterwards use the new synonyms
+	SkipInitialScan bool
+}
+
+// SynUpdate add terms to the synonym group {groupID}, the group is created if it does not exist.
+// Terms can not be removed from a group, only added
+func (r *RediSearch) SynUpdate(ctx stdContext.Context, index, groupID string, terms []string, opts SynUpdateOptions) error {
+	if index == "" || groupID == "" || len(terms) == 0 {
+		return errors.New("missing required index, groupID or terms")
+	}
+	args := []interface{}{"FT.SYNUPDATE", index, groupID}
+	if opts.SkipInitialScan {
+		args = append(args, "SKIPINITIALSCAN")
+	}
+	for _, term := range terms {
+		args = append(args, term)
+	}
+	do := r.client.Do(ctx, args...)
+	_, err := do.Result()
+	return err
+}
+
+// SynDump return the synonym groups of the index, as a map of group id to the (sorted) terms of the group
+func (r *RediSearch) SynDump(ctx stdContext.Context, index string) (map[string][]string, error) {
+	if index == "" {
+		return nil, errors.New("missing required index")
+	}
+	do := r.client.Do(ctx, "FT.SYNDUMP", index)
+	res, err := do.Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseSynDump(res)
+}
+
+// SynSync make sure every synonym group contains the given terms, only the missing terms are sent so syncing the same groups again is a no-op.
+// Terms (and groups) not present in {groups} are kept, as redisearch does not support removing them. It returns the ids of the updated groups.
+// Groups can be read from a CSV file using ReadSynonymsCSV, or from any other format decoded into a map[string][]string
+func (r *RediSearch) SynSync(ctx stdContext.Context, index string, groups map[string][]string, opts SynUpdateOptions) ([]string, error) {
+	current, err := r.SynDump(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	var updated []string
+	for _, groupID := range sortedGroupIDs(groups) {
+		missing := missingSynonyms(current[groupID], groups[groupID])
+		if len(missing) == 0 {
+			continue
+		}
+		if err := r.SynUpdate(ctx, index, groupID, missing, opts); err != nil {
+			return updated, fmt.Errorf("update synonym group %s: %w", groupID, err)
+		}
+		updated = append(updated, groupID)
+	}
+	return updated, nil
+}
+
+// ReadSynonymsCSV read synonym groups from a CSV file with one group per line: groupID,term1,term2,...
+// Lines starting with # are ignored, lines using the same group id are merged
+func ReadSynonymsCSV(reader io.Reader) (map[string][]string, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]string, len(records))
+	for _, record := range records {
+		groupID := strings.TrimSpace(record[0])
+		if groupID == "" {
+			continue
+		}
+		for _, term := range record[1:] {
+			if term = strings.TrimSpace(term); term != "" {
+				groups[groupID] = append(groups[groupID], term)
+			}
+		}
+	}
+	return groups, nil
+}
+
+// parseSynDump parse the FT.SYNDUMP reply ([term1 [groupID ...] term2 ...]) into a map of group id to terms
+func parseSynDump(raw interface{}) (map[string][]string, error) {
+	terms, err := infoMap(raw)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string][]string)
+	for term, groupIDs := range terms {
+		rawGroupIDs, ok := groupIDs.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid redis response groups: %v", groupIDs)
+		}
+		for _, groupID := range rawGroupIDs {
+			groups[infoString(groupID)] = append(groups[infoString(groupID)], term)
+		}
+	}
+	for _, groupTerms := range groups {
+		sort.Strings(groupTerms)
+	}
+	return groups, nil
+}
+
+// missingSynonyms return the desired terms that are not in the current group. Terms are compared in lowercase, as they are stored by redisearch
+func missingSynonyms(current, desired []string) []string {
+	existing := make(map[string]bool, len(current))
+	for _, term := range current {
+		existing[strings.ToLower(term)] = true
+	}
+	var missing []string
+	for _, term := range desired {
+		if !existing[strings.ToLower(term)] {
+			existing[strings.ToLower(term)] = true
+			missing = append(missing, term)
+		}
+	}
+	return missing
+}
+
+func sortedGroupIDs(groups map[string][]string) []string {
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,146 @@
+package redisearch
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseSynDump(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     interface{}
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "ok: resp2",
+			raw: []interface{}{
+				"tv", []interface{}{"g1"},
+				"television", []interface{}{"g1"},
+				"car", []interface{}{"g2", "g3"},
+			},
+			want: map[string][]string{
+				"g1": {"television", "tv"},
+				"g2": {"car"},
+				"g3": {"car"},
+			},
+		},
+		{
+			name: "ok: resp3",
+			raw: map[interface{}]interface{}{
+				"tv":         []interface{}{"g1"},
+				"television": []interface{}{"g1"},
+			},
+			want: map[string][]string{"g1": {"television", "tv"}},
+		},
+		{
+			name: "ok: empty",
+			raw:  []interface{}{},
+			want: map[string][]string{},
+		},
+		{
+			name:    "invalid groups",
+			raw:     []interface{}{"tv", "g1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSynDump(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSynDump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSynDump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_missingSynonyms(t *testing.T) {
+	got := missingSynonyms([]string{"television", "tv"}, []string{"TV", "Television", "telly", "telly"})
+	want := []string{"telly"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingSynonyms() = %v, want %v", got, want)
+	}
+	if got := missingSynonyms([]string{"tv"}, []string{"tv"}); got != nil {
+		t.Errorf("missingSynonyms() = %v, want nil", got)
+	}
+}
+
+func TestReadSynonymsCSV(t *testing.T) {
+	csv := `# group,terms...
+tv, tv, television
+car,car,automobile
+tv,telly
+,ignored
+`
+	got, err := ReadSynonymsCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ReadSynonymsCSV() error = %v", err)
+	}
+	want := map[string][]string{
+		"tv":  {"tv", "television", "telly"},
+		"car": {"car", "automobile"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadSynonymsCSV() = %v, want %v", got, want)
+	}
+}
+
+func TestRediSearch_SynSync(t *testing.T) {
+	// synonyms stored by the server, term -> group ids
+	stored := map[string][]interface{}{
+		"tv":    {"tv"},
+		"phone": {"phone"},
+	}
+	r, rec := newTestClient(func(args []interface{}) (interface{}, error) {
+		switch args[0] {
+		case "FT.SYNDUMP":
+			var reply []interface{}
+			for term, groupIDs := range stored {
+				reply = append(reply, term, groupIDs)
+			}
+			return reply, nil
+		case "FT.SYNUPDATE":
+			for _, term := range args[3:] {
+				key := strings.ToLower(term.(string))
+				stored[key] = append(stored[key], args[2])
+			}
+		}
+		return "OK", nil
+	})
+	groups := map[string][]string{
+		"tv":    {"TV", "television"},
+		"phone": {"phone"},
+		"car":   {"car", "automobile"},
+	}
+	ctx := context.Background()
+	updated, err := r.SynSync(ctx, "products", groups, SynUpdateOptions{})
+	if err != nil {
+		t.Fatalf("SynSync() error = %v", err)
+	}
+	if !reflect.DeepEqual(updated, []string{"car", "tv"}) {
+		t.Errorf("SynSync() updated = %v, want [car tv]", updated)
+	}
+	want := [][]interface{}{
+		{"FT.SYNDUMP", "products"},
+		{"FT.SYNUPDATE", "products", "car", "car", "automobile"},
+		{"FT.SYNUPDATE", "products", "tv", "television"},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SynSync() commands = %v, want %v", got, want)
+	}
+
+	// everything is in sync, only the current groups are read
+	updated, err = r.SynSync(ctx, "products", groups, SynUpdateOptions{})
+	if err != nil || len(updated) != 0 {
+		t.Errorf("SynSync() second run = %v, %v, want no updates", updated, err)
+	}
+	if got := rec.commands(); len(got) != len(want)+1 || got[len(got)-1][0] != "FT.SYNDUMP" {
+		t.Errorf("SynSync() second run commands = %v, want only FT.SYNDUMP", got[len(want):])
+	}
+}
@@ -3,6 +3,12 @@ package redisearch
 import "fmt"
 
 const (
+	// IndexOnHash index documents stored as hashes (HSET). This is the default
+	IndexOnHash string = "HASH"
+	// IndexOnJSON index documents stored as JSON (JSON.SET). Requires the RedisJSON module.
+	// Schema fields must be JSONPath expressions (ie: $.name), use FieldSchema.As to set the attribute name used in queries
+	IndexOnJSON string = "JSON"
+
 	// FieldTypeText Allows full-text search queries against the value in this field.
 	FieldTypeText string = "TEXT"
 	// FieldTypeTag Allows exact-match queries, such as categories or primary keys,
@@ -14,6 +20,9 @@ const (
 	// FieldTypeGeo Allows geographic range queries against the value in this field.
 	// The value of the field must be a string containing a longitude (first) and latitude separated by a comma.
 	FieldTypeGeo string = "GEO"
+	// FieldTypeVector Allows vector similarity queries against the value in this field.
+	// Must be used along with SchemaOptVectorFlat or SchemaOptVectorHNSW
+	FieldTypeVector string = "VECTOR"
 
 	// IndexFlagNoOffsets If set, we do not store term offsets for documents (saves memory, does not allow exact searches or highlighting). Implies NOHL .
 	IndexFlagNoOffsets string = "NOOFFSETS"
@@ -34,17 +43,16 @@ const (
 	// SearchFlagNoStopWords If set, we do not filter stopwords from the query.
 	SearchFlagNoStopWords = "NOSTOPWORDS"
 
-	// NOT SUPPORTED ATM, search results parser needs to be updated to understand these
 	// SearchFlagNoContent If it appears after the query, we only return the document ids and not the content. This is useful if RediSearch is only an index on an external document collection
-	// SearchFlagNoContent = "NOCONTENT"
+	SearchFlagNoContent = "NOCONTENT"
 	// SearchFlagWithScores If set, we also return the relative internal score of each document. this can be used to merge results from multiple instances
-	// SearchFlagWithScores = "WITHSCORES"
-	// SearchFlagWithPayloads //  If set, we retrieve optional document payloads (see FT.ADD).
+	SearchFlagWithScores = "WITHSCORES"
+	// SearchFlagWithPayloads If set, we retrieve optional document payloads (see FT.ADD).
 	// the payloads follow the document id, and if WITHSCORES was set, follow the scores.
-	// SearchFlagWithPayloads = "WITHPAYLOADS"
+	SearchFlagWithPayloads = "WITHPAYLOADS"
 	// SearchFlagWithSortKeys Only relevant in conjunction with SORTBY . Returns the value of the sorting key, right after the id and score and /or payload if requested.
 	// This is usually not needed by users, and exists for distributed search coordination purposes.
-	// SearchFlagWithSortKeys = "WITHSORTKEYS"
+	SearchFlagWithSortKeys = "WITHSORTKEYS"
 )
 
 type SchemaOpt []interface{}
@@ -70,7 +78,7 @@ func SchemaOptSortable() SchemaOpt {
 // SchemaOptTagSeparator or TAG fields, indicates how the text contained in the field is to be split into individual tags.
 // The default is , . The value must be a single character
 func SchemaOptTagSeparator(character byte) SchemaOpt {
-	return []interface{}{"SEPARATOR", character}
+	return []interface{}{"SEPARATOR", string(character)}
 }
 
 // SchemaOptNoIndex Fields can have the NOINDEX option, which means they will not be indexed. This is useful in conjunction with SORTABLE,
@@ -94,13 +102,18 @@ func SchemaOptPhonetic(matcher string) SchemaOpt {
 type FieldSchema struct {
 	// Field types can be numeric, textual or geographical.
 	// See FieldDataType constants
-	Type    string
+	Type string
+	// As optional attribute name used to reference the field in queries and results.
+	// Required for JSON indexes, where the field name is a JSONPath expression
+	As      string
 	Options []SchemaOpt
 }
 type IndexOptions struct {
 	// The index name to create. If it exists the old spec will be overwritten
 	// This is a REQUIRED field
 	IndexName string
+	// On the type of documents to index, see IndexOn* constants. Defaults to HASH
+	On string
 	// Tells the index which keys it should index.
 	// You can add several prefixes to index. Since the argument is optional, the default is * (all keys)
 	Prefix []string
@@ -236,4 +249,35 @@ type SearchOptions struct {
 	Limit *Limit
 	// Flags see SearchFlag* constants
 	Flags []string
+	// Params values bound server side to the $name placeholders of the query, so user input does not need to be escaped.
+	// []float32 and []float64 values are encoded as vector blobs. Requires DIALECT 2, which is used by default when Params are set
+	Params map[string]interface{}
+	// Dialect the query dialect version. If not set the server default is used (unless Params or Vector are set)
+	Dialect int
+	// Vector If set, the query is turned into a KNN (or range) vector similarity query over the given vector field.
+	// The query vector is sent as a query parameter and DIALECT 2 is used. The distance is returned as the VectorQuery.ScoreAlias field
+	Vector *VectorQuery
+}
+
+// Document a single search hit
+type Document struct {
+	// Key the hash key of the document
+	Key string
+	// Score relative internal score of the document. Only set if SearchFlagWithScores is used
+	Score float64
+	// Payload optional document payload. Only set if SearchFlagWithPayloads is used
+	Payload string
+	// SortKey value of the sorting key. Only set if SearchFlagWithSortKeys is used along with SortBy
+	SortKey string
+	// Fields document content. Empty if SearchFlagNoContent is used
+	Fields map[string]string
+}
+
+// SearchResult hold the total number of hits and the returned documents
+type SearchResult struct {
+	Total int64
+	Docs  []Document
+	// Warnings values ignored by the last call to Scan because they could not be decoded (see DecodeOptions)
+	Warnings []*DecodeError
+	decode   DecodeOptions
 }
@@ -0,0 +1,214 @@
+package redisearch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+const (
+	// VectorAlgorithmFlat Brute force algorithm
+	VectorAlgorithmFlat = "FLAT"
+	// VectorAlgorithmHNSW Hierarchical Navigable Small World graph algorithm, approximated but much faster on big data sets
+	VectorAlgorithmHNSW = "HNSW"
+
+	// VectorTypeFloat32 vector elements are stored as 32 bits floats ([]float32)
+	VectorTypeFloat32 = "FLOAT32"
+	// VectorTypeFloat64 vector elements are stored as 64 bits floats ([]float64)
+	VectorTypeFloat64 = "FLOAT64"
+
+	// DistanceMetricL2 Euclidean distance between two vectors
+	DistanceMetricL2 = "L2"
+	// DistanceMetricIP Inner product of two vectors
+	DistanceMetricIP = "IP"
+	// DistanceMetricCosine Cosine distance of two vectors
+	DistanceMetricCosine = "COSINE"
+
+	// defaultVectorParam name of the query parameter holding the vector blob
+	defaultVectorParam = "vector"
+)
+
+type VectorOptions struct {
+	// Type vector elements type. See VectorType* constants. REQUIRED
+	Type string
+	// Dim vector dimension, the number of elements of each vector. REQUIRED
+	Dim int
+	// DistanceMetric See DistanceMetric* constants. REQUIRED
+	DistanceMetric string
+	// InitialCap Initial vector capacity in the index, affecting memory allocation size of the index
+	InitialCap int
+	// BlockSize FLAT only. Block size to hold BLOCK_SIZE amount of vectors in a contiguous array
+	BlockSize int
+	// M HNSW only. Number of maximum allowed outgoing edges for each node in the graph in each layer. Default is 16
+	M int
+	// EFConstruction HNSW only. Number of maximum allowed potential outgoing edges candidates for each node in the graph, during the graph building. Default is 200
+	EFConstruction int
+	// EFRuntime HNSW only. Number of maximum top candidates to hold during the KNN search. Default is 10
+	EFRuntime int
+}
+
+// SchemaOptVectorFlat Vector fields using the FLAT (brute force) algorithm. Must be used along with FieldTypeVector
+func SchemaOptVectorFlat(opts VectorOptions) SchemaOpt {
+	attrs := opts.attrs()
+	if opts.BlockSize > 0 {
+		attrs = append(attrs, "BLOCK_SIZE", opts.BlockSize)
+	}
+	return vectorSchemaOpt(VectorAlgorithmFlat, attrs)
+}
+
+// SchemaOptVectorHNSW Vector fields using the HNSW algorithm. Must be used along with FieldTypeVector
+func SchemaOptVectorHNSW(opts VectorOptions) SchemaOpt {
+	attrs := opts.attrs()
+	if opts.M > 0 {
+		attrs = append(attrs, "M", opts.M)
+	}
+	if opts.EFConstruction > 0 {
+		attrs = append(attrs, "EF_CONSTRUCTION", opts.EFConstruction)
+	}
+	if opts.EFRuntime > 0 {
+		attrs = append(attrs, "EF_RUNTIME", opts.EFRuntime)
+	}
+	return vectorSchemaOpt(VectorAlgorithmHNSW, attrs)
+}
+
+func (o VectorOptions) attrs() []interface{} {
+	attrs := []interface{}{
+		"TYPE", o.Type,
+		"DIM", o.Dim,
+		"DISTANCE_METRIC", o.DistanceMetric,
+	}
+	if o.InitialCap > 0 {
+		attrs = append(attrs, "INITIAL_CAP", o.InitialCap)
+	}
+	return attrs
+}
+
+func vectorSchemaOpt(algorithm string, attrs []interface{}) SchemaOpt {
+	opt := []interface{}{algorithm, len(attrs)}
+	return append(opt, attrs...)
+}
+
+type VectorQuery struct {
+	// FieldName the vector field to query
+	FieldName string
+	// Vector the query vector. []float32 and []float64 are encoded as little-endian blobs, []byte and string are sent as they are.
+	// It is sent as the "vector" query parameter, so that name can not be used in SearchOptions.Params
+	Vector interface{}
+	// K number of nearest neighbours to return (KNN query), it must be greater than 0 unless Radius is set (both can not be set).
+	// Note that the number of returned results is still subject to Limit (10 by default)
+	K int
+	// Radius if set a range query is used instead of KNN, returning all the documents within the given distance
+	Radius float64
+	// ScoreAlias name of the field holding the distance in the results. Defaults to __{FieldName}_score
+	ScoreAlias string
+}
+
+// validate check the vector query before it is sent, {params} are the query parameters set by the user.
+// A nil vector query is valid
+func (q *VectorQuery) validate(params map[string]interface{}) error {
+	if q == nil {
+		return nil
+	}
+	if q.FieldName == "" {
+		return errors.New("missing required vector FieldName")
+	}
+	if q.Radius > 0 && q.K > 0 {
+		return errors.New("vector query {K} and {Radius} can not be both set")
+	}
+	if q.Radius <= 0 && q.K <= 0 {
+		return errors.New("vector query {K} must be greater than 0")
+	}
+	if _, ok := params[defaultVectorParam]; ok {
+		return fmt.Errorf("query parameter %q is reserved for the query vector", defaultVectorParam)
+	}
+	return nil
+}
+
+// scoreAlias return the name of the field holding the distance in the results
+func (q *VectorQuery) scoreAlias() string {
+	if q.ScoreAlias != "" {
+		return q.ScoreAlias
+	}
+	return "__" + q.FieldName + "_score"
+}
+
+// query wrap the base query with the KNN or range vector clause
+func (q *VectorQuery) query(base string) string {
+	if base == "" {
+		base = "*"
+	}
+	if q.Radius > 0 {
+		clause := fmt.Sprintf("@%s:[VECTOR_RANGE %v $%s]=>{$YIELD_DISTANCE_AS: %s}", q.FieldName, q.Radius, defaultVectorParam, q.scoreAlias())
+		if base == "*" {
+			return clause
+		}
+		return fmt.Sprintf("(%s) %s", base, clause)
+	}
+	return fmt.Sprintf("(%s)=>[KNN %d @%s $%s AS %s]", base, q.K, q.FieldName, defaultVectorParam, q.scoreAlias())
+}
+
+// VectorBlob encode a []float32 or []float64 vector as a little-endian blob, the format expected by vector fields
+func VectorBlob(vector interface{}) ([]byte, error) {
+	blob, ok := vectorBlob(reflect.ValueOf(vector))
+	if !ok {
+		return nil, fmt.Errorf("unsupported vector type: %T", vector)
+	}
+	return blob, nil
+}
+
+func vectorBlob(v reflect.Value) ([]byte, bool) {
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	switch v.Type().Elem().Kind() {
+	case reflect.Float32:
+		blob := make([]byte, v.Len()*4)
+		for i := 0; i < v.Len(); i++ {
+			binary.LittleEndian.PutUint32(blob[i*4:], math.Float32bits(float32(v.Index(i).Float())))
+		}
+		return blob, true
+	case reflect.Float64:
+		blob := make([]byte, v.Len()*8)
+		for i := 0; i < v.Len(); i++ {
+			binary.LittleEndian.PutUint64(blob[i*8:], math.Float64bits(v.Index(i).Float()))
+		}
+		return blob, true
+	}
+	return nil, false
+}
+
+// setVectorFromBlob decode a little-endian blob into a []float32 or []float64 field. It returns false if the field is not a vector,
+// and an error if the blob length is not a multiple of the element size (the field is not modified)
+func setVectorFromBlob(field reflect.Value, value string) (bool, error) {
+	if field.Kind() != reflect.Slice {
+		return false, nil
+	}
+	blob := []byte(value)
+	switch field.Type().Elem().Kind() {
+	case reflect.Float32:
+		if len(blob)%4 != 0 {
+			return true, fmt.Errorf("invalid FLOAT32 vector blob length %d, it must be a multiple of 4", len(blob))
+		}
+		n := len(blob) / 4
+		vector := reflect.MakeSlice(field.Type(), n, n)
+		for i := 0; i < n; i++ {
+			vector.Index(i).SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(blob[i*4:]))))
+		}
+		field.Set(vector)
+		return true, nil
+	case reflect.Float64:
+		if len(blob)%8 != 0 {
+			return true, fmt.Errorf("invalid FLOAT64 vector blob length %d, it must be a multiple of 8", len(blob))
+		}
+		n := len(blob) / 8
+		vector := reflect.MakeSlice(field.Type(), n, n)
+		for i := 0; i < n; i++ {
+			vector.Index(i).SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(blob[i*8:])))
+		}
+		field.Set(vector)
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,133 @@
+package redisearch
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaOptVector(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  SchemaOpt
+		want SchemaOpt
+	}{
+		{
+			name: "ok: flat",
+			opt:  SchemaOptVectorFlat(VectorOptions{Type: VectorTypeFloat32, Dim: 128, DistanceMetric: DistanceMetricCosine, BlockSize: 1024, M: 8}),
+			want: SchemaOpt{"FLAT", 8, "TYPE", "FLOAT32", "DIM", 128, "DISTANCE_METRIC", "COSINE", "BLOCK_SIZE", 1024},
+		},
+		{
+			name: "ok: hnsw",
+			opt:  SchemaOptVectorHNSW(VectorOptions{Type: VectorTypeFloat64, Dim: 3, DistanceMetric: DistanceMetricL2, InitialCap: 100, M: 16, EFConstruction: 200}),
+			want: SchemaOpt{"HNSW", 12, "TYPE", "FLOAT64", "DIM", 3, "DISTANCE_METRIC", "L2", "INITIAL_CAP", 100, "M", 16, "EF_CONSTRUCTION", 200},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.opt, tt.want) {
+				t.Errorf("got %v, want %v", tt.opt, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchOptions_Args_vector(t *testing.T) {
+	blob, _ := VectorBlob([]float32{1, 2})
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want []interface{}
+	}{
+		{
+			name: "ok: knn",
+			opts: SearchOptions{
+				IndexName: "idx",
+				Query:     "@tags:{shoes}",
+				Return:    []string{"name"},
+				Vector:    &VectorQuery{FieldName: "embedding", Vector: []float32{1, 2}, K: 5},
+			},
+			want: []interface{}{
+				"FT.SEARCH", "idx", "(@tags:{shoes})=>[KNN 5 @embedding $vector AS __embedding_score]",
+				"RETURN", 2, "name", "__embedding_score",
+				"SORTBY", "__embedding_score", "ASC",
+				"PARAMS", 2, "vector", blob, "DIALECT", 2,
+			},
+		},
+		{
+			name: "ok: range",
+			opts: SearchOptions{
+				IndexName: "idx",
+				Vector:    &VectorQuery{FieldName: "embedding", Vector: "raw", Radius: 0.5, ScoreAlias: "dist"},
+			},
+			want: []interface{}{
+				"FT.SEARCH", "idx", "@embedding:[VECTOR_RANGE 0.5 $vector]=>{$YIELD_DISTANCE_AS: dist}",
+				"PARAMS", 2, "vector", "raw", "DIALECT", 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Args(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchOptions.Args() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVectorQuery_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *VectorQuery
+		params  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "ok: nil", query: nil},
+		{name: "ok: knn", query: &VectorQuery{FieldName: "embedding", K: 5}, params: map[string]interface{}{"tag": "shoes"}},
+		{name: "ok: range without K", query: &VectorQuery{FieldName: "embedding", Radius: 0.5}},
+		{name: "K and radius", query: &VectorQuery{FieldName: "embedding", K: 5, Radius: 0.5}, wantErr: true},
+		{name: "missing field name", query: &VectorQuery{K: 5}, wantErr: true},
+		{name: "zero K", query: &VectorQuery{FieldName: "embedding"}, wantErr: true},
+		{name: "negative K", query: &VectorQuery{FieldName: "embedding", K: -1}, wantErr: true},
+		{name: "reserved param", query: &VectorQuery{FieldName: "embedding", K: 5}, params: map[string]interface{}{"vector": "x"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query.validate(tt.params); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	r, rec := newTestClient(nil)
+	_, err := r.Search(context.Background(), SearchOptions{IndexName: "idx", Vector: &VectorQuery{FieldName: "embedding"}}, &[]map[string]string{})
+	if err == nil || len(rec.commands()) != 0 {
+		t.Errorf("Search() error = %v, commands = %v, want the query to be rejected before it is sent", err, rec.commands())
+	}
+}
+
+func TestVectorBlob(t *testing.T) {
+	blob, err := VectorBlob([]float32{1.5, -2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0, 0, 0xc0, 0x3f, 0, 0, 0, 0xc0}
+	if !reflect.DeepEqual(blob, want) {
+		t.Errorf("VectorBlob() = %v, want %v", blob, want)
+	}
+	if _, err := VectorBlob([]int{1}); err == nil {
+		t.Error("VectorBlob() expected error for unsupported type")
+	}
+
+	var out []struct {
+		Embedding []float32 `json:"embedding"`
+		Distance  float64   `json:"__embedding_score"`
+	}
+	raw := []interface{}{int64(1), "doc:1", []interface{}{"embedding", string(blob), "__embedding_score", "0.25"}}
+	if _, err := parseSearchResults(raw, nil, &out, DecodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || !reflect.DeepEqual(out[0].Embedding, []float32{1.5, -2}) || out[0].Distance != 0.25 {
+		t.Errorf("parseSearchResults() = %+v", out)
+	}
+}
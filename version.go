@@ -0,0 +1,98 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+)
+
+// VersionField hash field used by PutIfVersion to store the version of the document
+const VersionField = "__version"
+
+// ConflictError returned by PutIfVersion when the document was modified by someone else
+type ConflictError struct {
+	Key string
+	// Expected the version the write was based on
+	Expected int64
+	// Actual the current version of the document, -1 if it could not be read
+	Actual int64
+}
+
+func (e *ConflictError) Error() string {
+	if e.Expected == 0 && e.Actual == 0 {
+		return fmt.Sprintf("version conflict on %s: expected a new document, but the key already exists", e.Key)
+	}
+	return fmt.Sprintf("version conflict on %s: expected version %d, got %d", e.Key, e.Expected, e.Actual)
+}
+
+// PutIfVersion same as Put, but the document is only written if its current version (stored in the VersionField hash field) is {version}.
+// Use version 0 for documents that do not exist yet, existing keys without a version (ie: written by Put) are conflicts. The key is watched (WATCH) while the version is checked, so concurrent writes are detected.
+// It returns the new version of the document, or a *ConflictError if the document was modified by someone else
+func (r *RediSearch) PutIfVersion(ctx stdContext.Context, key string, value interface{}, version int64, override bool) (int64, error) {
+	if key == "" || value == nil {
+		return 0, errors.New("invalid key or nil value")
+	}
+	values, err := encodeHash(value)
+	if err != nil {
+		return 0, err
+	}
+	values = append(values, VersionField, version+1)
+
+	err = r.client.Watch(ctx, func(tx *redis.Tx) error {
+		if err := checkVersion(ctx, tx, key, version); err != nil {
+			return err
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if override {
+				pipe.Del(ctx, key)
+			}
+			pipe.HSet(ctx, key, values...)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		// modified between the version check and the write
+		actual, readErr := documentVersion(ctx, r.client, key)
+		if readErr != nil {
+			actual = -1
+		}
+		return 0, &ConflictError{Key: key, Expected: version, Actual: actual}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return version + 1, nil
+}
+
+// checkVersion return a *ConflictError if the current version of the document is not {expected}.
+// Version 0 means the document must not exist, so the key is checked as well when there is no version
+func checkVersion(ctx stdContext.Context, client redis.Cmdable, key string, expected int64) error {
+	current, err := documentVersion(ctx, client, key)
+	if err != nil {
+		return err
+	}
+	if current != expected {
+		return &ConflictError{Key: key, Expected: expected, Actual: current}
+	}
+	if expected == 0 {
+		exists, err := client.Exists(ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			return &ConflictError{Key: key, Expected: expected, Actual: current}
+		}
+	}
+	return nil
+}
+
+// documentVersion return the current version of the document, 0 if the document (or its version) does not exist
+func documentVersion(ctx stdContext.Context, client redis.Cmdable, key string) (int64, error) {
+	version, err := client.HGet(ctx, key, VersionField).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
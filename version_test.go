@@ -0,0 +1,124 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestConflictError(t *testing.T) {
+	var err error = fmt.Errorf("save: %w", &ConflictError{Key: "doc:1", Expected: 2, Actual: 3})
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("errors.As() = false, want ConflictError")
+	}
+	want := "version conflict on doc:1: expected version 2, got 3"
+	if got := conflict.Error(); got != want {
+		t.Errorf("ConflictError.Error() = %v, want %v", got, want)
+	}
+	want = "version conflict on doc:1: expected a new document, but the key already exists"
+	if got := (&ConflictError{Key: "doc:1"}).Error(); got != want {
+		t.Errorf("ConflictError.Error() = %v, want %v", got, want)
+	}
+}
+
+// newVersionTestClient return a client where doc:1 is at version 2, doc:2 does not exist and doc:3 exists without a version
+func newVersionTestClient() (*RediSearch, *testRecorder) {
+	return newTestClient(func(args []interface{}) (interface{}, error) {
+		switch args[0] {
+		case "hget":
+			if args[1] == "doc:1" {
+				return "2", nil
+			}
+			return nil, nil
+		case "exists":
+			if args[1] == "doc:2" {
+				return int64(0), nil
+			}
+		}
+		return int64(1), nil
+	})
+}
+
+func Test_checkVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		expected   int64
+		wantActual int64
+		wantErr    bool
+	}{
+		{name: "ok: match", key: "doc:1", expected: 2},
+		{name: "ok: missing key is version 0", key: "doc:2", expected: 0},
+		{name: "mismatch", key: "doc:1", expected: 1, wantActual: 2, wantErr: true},
+		{name: "missing key", key: "doc:2", expected: 3, wantActual: 0, wantErr: true},
+		{name: "existing key without version", key: "doc:3", expected: 0, wantActual: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := newVersionTestClient()
+			err := checkVersion(context.Background(), r.client, tt.key, tt.expected)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("checkVersion() error = %v", err)
+				}
+				return
+			}
+			var conflict *ConflictError
+			if !errors.As(err, &conflict) || conflict.Expected != tt.expected || conflict.Actual != tt.wantActual {
+				t.Errorf("checkVersion() error = %v, want conflict %d -> %d", err, tt.expected, tt.wantActual)
+			}
+		})
+	}
+}
+
+func TestRediSearch_PutIfVersion(t *testing.T) {
+	ctx := context.Background()
+	r, rec := newVersionTestClient()
+	version, err := r.PutIfVersion(ctx, "doc:1", map[string]string{"name": "a"}, 2, true)
+	if err != nil || version != 3 {
+		t.Fatalf("PutIfVersion() = %d, %v, want 3", version, err)
+	}
+	want := [][]interface{}{
+		{"watch", "doc:1"},
+		{"hget", "doc:1", VersionField},
+		{"multi"},
+		{"del", "doc:1"},
+		{"hset", "doc:1", "name", "a", VersionField, int64(3)},
+		{"exec"},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PutIfVersion() commands = %v, want %v", got, want)
+	}
+
+	r, rec = newVersionTestClient()
+	_, err = r.PutIfVersion(ctx, "doc:1", map[string]string{"name": "a"}, 1, false)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Actual != 2 {
+		t.Errorf("PutIfVersion() error = %v, want conflict with version 2", err)
+	}
+	if got := rec.commands(); len(got) != 2 {
+		t.Errorf("PutIfVersion() commands = %v, want only WATCH and HGET on conflict", got)
+	}
+
+	r, rec = newVersionTestClient()
+	_, err = r.PutIfVersion(ctx, "doc:3", map[string]string{"name": "a"}, 0, false)
+	if !errors.As(err, &conflict) || conflict.Expected != 0 {
+		t.Errorf("PutIfVersion() error = %v, want conflict for the existing key", err)
+	}
+	want = [][]interface{}{
+		{"watch", "doc:3"},
+		{"hget", "doc:3", VersionField},
+		{"exists", "doc:3"},
+	}
+	if got := rec.commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PutIfVersion() commands = %v, want %v", got, want)
+	}
+
+	r, _ = newVersionTestClient()
+	if version, err := r.PutIfVersion(ctx, "doc:2", map[string]string{"name": "a"}, 0, false); err != nil || version != 1 {
+		t.Errorf("PutIfVersion() = %d, %v, want 1 for a new document", version, err)
+	}
+}
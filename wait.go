@@ -0,0 +1,81 @@
+package redisearch
+
+import (
+	stdContext "context"
+	"errors"
+	"time"
+)
+
+type WaitOptions struct {
+	// Interval initial polling interval. Defaults to 100ms
+	Interval time.Duration
+	// MaxInterval the polling interval is doubled after each check until it reaches MaxInterval. Defaults to 5s
+	MaxInterval time.Duration
+	// Progress optional callback called after each check with the index info (PercentIndexed, NumDocs, etc.)
+	Progress func(info *IndexInfo)
+	// Timeout maximum time to wait for the index. Defaults to 10m, the context deadline applies too if it is sooner
+	Timeout time.Duration
+}
+
+// WaitForIndex block until the background scan of the index (or alias) is complete, polling FT.INFO with an exponential backoff.
+// It returns the context error (context.DeadlineExceeded after opts.Timeout) if the context is done before the index is ready
+func (r *RediSearch) WaitForIndex(ctx stdContext.Context, name string, opts WaitOptions) error {
+	if name == "" {
+		return errors.New("missing required index name")
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+	ctx, cancel := stdContext.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 5 * time.Second
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		info, err := r.IndexInfo(ctx, name)
+		if err != nil {
+			return err
+		}
+		if opts.Progress != nil {
+			opts.Progress(info)
+		}
+		if indexReady(info) {
+			return nil
+		}
+
+		timer.Reset(interval)
+		interval = nextInterval(interval, maxInterval)
+	}
+}
+
+// nextInterval double the polling {interval}, up to {maxInterval}
+func nextInterval(interval, maxInterval time.Duration) time.Duration {
+	if interval *= 2; interval > maxInterval {
+		return maxInterval
+	}
+	return interval
+}
+
+// indexReady return true if the background scan is complete
+func indexReady(info *IndexInfo) bool {
+	return !info.Indexing && info.PercentIndexed >= 1
+}
@@ -0,0 +1,103 @@
+package redisearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_indexReady(t *testing.T) {
+	tests := []struct {
+		name string
+		info *IndexInfo
+		want bool
+	}{
+		{name: "indexing", info: &IndexInfo{Indexing: true, PercentIndexed: 0.4}, want: false},
+		{name: "indexing flag cleared before percent", info: &IndexInfo{PercentIndexed: 0.99}, want: false},
+		{name: "done", info: &IndexInfo{PercentIndexed: 1}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := indexReady(tt.info); got != tt.want {
+				t.Errorf("indexReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nextInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		want     time.Duration
+	}{
+		{name: "double", interval: time.Second, want: 2 * time.Second},
+		{name: "capped", interval: 3 * time.Second, want: 5 * time.Second},
+		{name: "max", interval: 5 * time.Second, want: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextInterval(tt.interval, 5*time.Second); got != tt.want {
+				t.Errorf("nextInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newWaitTestClient return a client whose FT.INFO replies report the given percent_indexed values, the last one is repeated
+func newWaitTestClient(percents ...string) (*RediSearch, *testRecorder) {
+	calls := 0
+	return newTestClient(func(args []interface{}) (interface{}, error) {
+		percent := percents[len(percents)-1]
+		if calls < len(percents) {
+			percent = percents[calls]
+		}
+		calls++
+		return []interface{}{"index_name", args[1], "indexing", "0", "percent_indexed", percent}, nil
+	})
+}
+
+func TestRediSearch_WaitForIndex(t *testing.T) {
+	ctx := context.Background()
+
+	r, rec := newWaitTestClient("0.5", "1")
+	var progress []float64
+	err := r.WaitForIndex(ctx, "cities", WaitOptions{Interval: time.Millisecond, Progress: func(info *IndexInfo) {
+		progress = append(progress, info.PercentIndexed)
+	}})
+	if err != nil {
+		t.Fatalf("WaitForIndex() error = %v", err)
+	}
+	if len(rec.commands()) != 2 || len(progress) != 2 || progress[0] != 0.5 || progress[1] != 1 {
+		t.Errorf("WaitForIndex() commands = %v, progress = %v, want 2 checks", rec.commands(), progress)
+	}
+
+	// the first check is not delayed
+	r, _ = newWaitTestClient("1")
+	start := time.Now()
+	if err := r.WaitForIndex(ctx, "cities", WaitOptions{Interval: time.Hour}); err != nil || time.Since(start) > time.Second {
+		t.Errorf("WaitForIndex() error = %v after %v, want no delay", err, time.Since(start))
+	}
+
+	failed := errors.New("Unknown Index name")
+	r, _ = newTestClient(func(args []interface{}) (interface{}, error) {
+		return nil, failed
+	})
+	if err := r.WaitForIndex(ctx, "cities", WaitOptions{}); !errors.Is(err, failed) {
+		t.Errorf("WaitForIndex() error = %v, want %v", err, failed)
+	}
+}
+
+func TestRediSearch_WaitForIndex_done(t *testing.T) {
+	r, _ := newWaitTestClient("0.5")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := r.WaitForIndex(ctx, "cities", WaitOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForIndex() error = %v, want context.Canceled", err)
+	}
+
+	if err := r.WaitForIndex(context.Background(), "cities", WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForIndex() error = %v, want context.DeadlineExceeded", err)
+	}
+}